@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/roset-dev/roset/monorepo/cli/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage CLI configuration",
+}
+
+func init() {
+	cli.MarkManagement(configCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/roset-dev/roset/monorepo/cli/pkg/cli"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/config"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/keychain"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var configCredentialBackendCmd = &cobra.Command{
+	Use:   "credential-backend [name]",
+	Short: "Show or override where API keys are stored",
+	Long: `With no argument, credential-backend prints which backend 'roset login'/
+'roset profile' currently use to store API keys: a docker-credential-helpers
+backend name (e.g. "secretservice", "osxkeychain", "wincred") or "file" if
+plaintext storage in config.yaml was chosen or the platform has no default.
+A backend whose helper is not on PATH is still named, with "Available:
+false"; keys are then stored in plaintext in config.yaml.
+
+With a name argument, it overrides the backend used for future saves: the
+name of a docker-credential-helpers backend that must already be installed
+on PATH, or "file" to force plaintext storage and stop using the OS
+keychain. This only affects keys saved after the override - run 'roset
+login' again to move an already-stored key onto the new backend.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigCredentialBackend,
+}
+
+func init() {
+	configCmd.AddCommand(configCredentialBackendCmd)
+}
+
+// credentialBackendResult is the JSON shape `roset config
+// credential-backend` prints with no argument.
+type credentialBackendResult struct {
+	Backend   string `json:"backend"`
+	Available bool   `json:"available"`
+}
+
+func runConfigCredentialBackend(cmd *cobra.Command, args []string) error {
+	if len(args) == 1 {
+		name := args[0]
+		if err := config.SetCredentialBackend(name); err != nil {
+			return cli.UserError(err)
+		}
+		output.New(jsonOutput).PrintSuccess(fmt.Sprintf("Credential backend set to %q.", name))
+		return nil
+	}
+
+	name := config.CredentialBackendName()
+	result := credentialBackendResult{
+		Backend:   name,
+		Available: name == "file" || keychain.NewHelper(name).Available(),
+	}
+
+	if jsonOutput {
+		output.New(true).PrintJSON(result)
+		return nil
+	}
+
+	fmt.Printf("%-14s %s\n", "Backend:", result.Backend)
+	fmt.Printf("%-14s %v\n", "Available:", result.Available)
+	return nil
+}
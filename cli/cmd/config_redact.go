@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/redact"
+	"github.com/spf13/cobra"
+)
+
+var redactCmd = &cobra.Command{
+	Use:   "redact",
+	Short: "Inspect redaction rules",
+}
+
+var redactListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the effective redaction rule set (built-ins + user rules)",
+	Run:   runRedactList,
+}
+
+func init() {
+	redactCmd.AddCommand(redactListCmd)
+	configCmd.AddCommand(redactCmd)
+}
+
+func runRedactList(cmd *cobra.Command, args []string) {
+	p := output.New(jsonOutput)
+	rules := redact.EffectiveRules()
+
+	if jsonOutput {
+		p.PrintJSON(rules)
+		return
+	}
+
+	rows := make([][]string, 0, len(rules))
+	for _, r := range rules {
+		status := "enabled"
+		if r.Disabled {
+			status = "disabled"
+		}
+		rows = append(rows, []string{r.Name, r.Source, status})
+	}
+	p.PrintTable([]string{"NAME", "SOURCE", "STATUS"}, rows)
+}
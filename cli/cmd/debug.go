@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/cli"
 	"github.com/roset-dev/roset/monorepo/cli/pkg/redact"
 	"github.com/spf13/cobra"
 )
@@ -31,17 +32,18 @@ var debugCmd = &cobra.Command{
 var bundleCmd = &cobra.Command{
 	Use:   "bundle",
 	Short: "Create a diagnostic bundle for troubleshooting",
-	Run:   runBundle,
+	RunE:  runBundle,
 }
 
 func init() {
 	bundleCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output path for the bundle")
 	bundleCmd.Flags().BoolVar(&noRedact, "no-redact", false, "Disable secret redaction (DANGEROUS)")
 	debugCmd.AddCommand(bundleCmd)
+	cli.MarkManagement(debugCmd)
 	rootCmd.AddCommand(debugCmd)
 }
 
-func runBundle(cmd *cobra.Command, args []string) {
+func runBundle(cmd *cobra.Command, args []string) error {
 	if noRedact {
 		color.Red("!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!")
 		color.Red("!! WARNING: Redaction is DISABLED. This bundle will       !!")
@@ -59,33 +61,59 @@ func runBundle(cmd *cobra.Command, args []string) {
 
 	tmpDir, err := os.MkdirTemp("", "roset-debug-*")
 	if err != nil {
-		fmt.Printf("Error creating temp dir: %v\n", err)
-		os.Exit(1)
+		return cli.SystemError(fmt.Errorf("creating temp dir: %w", err))
 	}
 	defer os.RemoveAll(tmpDir)
 
+	var collectors []CollectorResult
+	var report *redact.Report
+	if !noRedact {
+		report = redact.NewReport()
+	}
+
 	// 1. System Info
 	color.Blue("📋 Collecting system info...")
-	sysInfo := getSystemInfo()
-	writeJSON(filepath.Join(tmpDir, "system_info.json"), sysInfo)
+	collectors = append(collectors, timeCollector("system_info", func() {
+		writeJSON(filepath.Join(tmpDir, "system_info.json"), getSystemInfo())
+	}))
 
 	// 2. Mount Info
 	color.Blue("🏔️ Collecting mount info...")
-	mountInfo := getMountInfo()
-	writeJSON(filepath.Join(tmpDir, "mount_info.json"), mountInfo)
+	collectors = append(collectors, timeCollector("mount_info", func() {
+		writeJSON(filepath.Join(tmpDir, "mount_info.json"), getMountInfo())
+	}))
 
 	// 3. Logs
 	color.Blue("📜 Collecting logs...")
-	collectLogs(tmpDir)
+	collectors = append(collectors, timeCollector("logs", func() {
+		collectLogs(tmpDir, report)
+	}))
+
+	// 4. Manifest
+	color.Blue("📝 Signing manifest...")
+	fingerprint, err := writeManifest(tmpDir, collectors, report)
+	if err != nil {
+		return cli.SystemError(err)
+	}
 
-	// 4. Compress
+	// 5. Compress
 	color.Blue("📦 Creating archive %s...", outputFile)
 	if err := createArchive(tmpDir, outputFile); err != nil {
-		fmt.Printf("Error creating archive: %v\n", err)
-		os.Exit(1)
+		return cli.SystemError(fmt.Errorf("creating archive: %w", err))
 	}
 
 	color.Green("✅ Bundle created successfully: %s", outputFile)
+	fmt.Printf("Manifest signing key fingerprint: %s\n", fingerprint)
+	fmt.Println("Quote this fingerprint to support to confirm the bundle reached them unmodified.")
+	return nil
+}
+
+// timeCollector runs collect and returns how long it took, so every
+// collector's duration ends up in the bundle's manifest.
+func timeCollector(name string, collect func()) CollectorResult {
+	start := time.Now()
+	collect()
+	return CollectorResult{Name: name, DurationMs: time.Since(start).Milliseconds()}
 }
 
 func getSystemInfo() map[string]interface{} {
@@ -130,7 +158,11 @@ func getMountInfo() map[string]interface{} {
 	return map[string]interface{}{"all_fuse_roset_mounts": rosetMounts}
 }
 
-func collectLogs(tmpDir string) {
+// collectLogs copies each known log source into the bundle's logs dir,
+// scrubbing secrets as bytes flow through rather than reading the whole file
+// into memory first. That removes the old 1000-line truncation: a syslog or
+// journald dump of any size can now be redacted in bounded memory.
+func collectLogs(tmpDir string, report *redact.Report) {
 	logDir := filepath.Join(tmpDir, "logs")
 	if err := os.Mkdir(logDir, 0755); err != nil {
 		fmt.Printf("Warning: failed to create log dir: %v\n", err)
@@ -144,25 +176,38 @@ func collectLogs(tmpDir string) {
 	}
 
 	for _, src := range sources {
-		if _, err := os.Stat(src); err == nil {
-			// Read last 1000 lines (simplified for Go)
-			content, _ := os.ReadFile(src)
-			lines := strings.Split(string(content), "\n")
-			start := 0
-			if len(lines) > 1000 {
-				start = len(lines) - 1001
-			}
-			data := strings.Join(lines[start:], "\n")
-
-			if !noRedact {
-				data = redact.String(data)
-			}
+		if err := collectLogFile(src, logDir, report); err != nil {
+			fmt.Printf("Warning: failed to collect log file %s: %v\n", src, err)
+		}
+	}
+}
 
-			if err := os.WriteFile(filepath.Join(logDir, filepath.Base(src)), []byte(data), 0644); err != nil {
-				fmt.Printf("Warning: failed to write log file %s: %v\n", src, err)
-			}
+func collectLogFile(src, logDir string, report *redact.Report) error {
+	in, err := os.Open(src)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
 		}
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(filepath.Join(logDir, filepath.Base(src)), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if noRedact {
+		_, err = io.Copy(out, in)
+		return err
+	}
+
+	rw := redact.NewWriterWithReport(out, report)
+	if _, err := io.Copy(rw, in); err != nil {
+		return err
 	}
+	return rw.Close()
 }
 
 func writeJSON(path string, data interface{}) {
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/roset-dev/roset/monorepo/cli/pkg/analyzer"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/cli"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/redact"
+	"github.com/spf13/cobra"
+)
+
+var analyzeOffline bool
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze <credential>",
+	Short: "Report what a detected credential can actually do",
+	Long: `Analyze takes a secret value (typically one redact already flagged in a
+debug bundle) and asks the credential's own provider what it can do: who it
+belongs to, what it can reach, and when it expires.
+
+Supported credential types: AWS access keys, GitHub PATs, GitLab PATs, and
+generic JWTs.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAnalyze,
+}
+
+func init() {
+	analyzeCmd.Flags().BoolVar(&analyzeOffline, "offline", false, "Only decode/inspect the credential locally; never call out to the provider")
+	debugCmd.AddCommand(analyzeCmd)
+}
+
+func runAnalyze(cmd *cobra.Command, args []string) error {
+	p := output.New(jsonOutput)
+	secret := args[0]
+
+	credType := redact.Classify(secret)
+	if credType == "" {
+		return cli.UserError(fmt.Errorf("unrecognized credential format; none of the known patterns matched"))
+	}
+
+	a, ok := analyzer.For(credType)
+	if !ok {
+		return cli.UserError(fmt.Errorf("no analyzer registered for credential type %q", credType))
+	}
+
+	ctx, cancel := context.WithTimeout(analyzer.WithOffline(context.Background(), analyzeOffline), 30*time.Second)
+	defer cancel()
+
+	result, err := a.Analyze(ctx, secret)
+	if err != nil {
+		return cli.SystemError(err)
+	}
+
+	if jsonOutput {
+		p.PrintJSON(result)
+		return nil
+	}
+
+	fmt.Printf("Type:          %s\n", result.Type)
+	fmt.Printf("Resource Kind: %s\n", result.ResourceKind)
+	if result.Principal != "" {
+		fmt.Printf("Principal:     %s\n", result.Principal)
+	}
+	if result.Expiry != nil {
+		fmt.Printf("Expires:       %s\n", result.Expiry.Format(time.RFC3339))
+	}
+
+	if len(result.Permissions) > 0 {
+		fmt.Println()
+		rows := make([][]string, 0, len(result.Permissions))
+		for _, perm := range result.Permissions {
+			rows = append(rows, []string{perm.Resource, perm.Action, string(perm.Access)})
+		}
+		p.PrintTable([]string{"RESOURCE", "ACTION", "ACCESS"}, rows)
+	}
+
+	if len(result.Notes) > 0 {
+		fmt.Println()
+		for _, note := range result.Notes {
+			fmt.Println("* " + note)
+		}
+	}
+
+	return nil
+}
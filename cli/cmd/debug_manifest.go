@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/roset-dev/roset/monorepo/cli/pkg/redact"
+)
+
+// manifestName, sigName, and pubKeyName are the well-known file names
+// runBundle writes into every archive and bundleVerify looks for by name.
+const (
+	manifestName = "manifest.json"
+	sigName      = "manifest.json.sig"
+	pubKeyName   = "manifest.pub"
+)
+
+// BundleManifest describes the contents of a diagnostic bundle: what
+// produced it, what ran, and what got redacted, so support can decide
+// whether to trust it without having to open every file by hand.
+type BundleManifest struct {
+	Version    VersionInfo       `json:"version"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	NoRedact   bool              `json:"noRedact"`
+	Collectors []CollectorResult `json:"collectors"`
+	Files      []FileEntry       `json:"files"`
+	Redaction  *redact.Report    `json:"redactionSummary,omitempty"`
+}
+
+// CollectorResult records that one of runBundle's collection steps ran and
+// how long it took, so a slow or silently-skipped collector is visible.
+type CollectorResult struct {
+	Name       string `json:"name"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// FileEntry is the SHA-256 of one file in the bundle at the time the
+// manifest was signed, keyed by its path relative to the archive root.
+type FileEntry struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// hashFile returns the hex-encoded SHA-256 of the file at path.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashTree walks dir and returns a FileEntry per regular file, with Path
+// relative to dir, sorted by filepath.Walk's natural lexical order.
+func hashTree(dir string) ([]FileEntry, error) {
+	var entries []FileEntry
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		sum, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, FileEntry{Path: rel, SHA256: sum})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// signManifest generates an ephemeral ed25519 keypair, signs raw, and
+// returns the signature, the public key, and a short fingerprint of the
+// public key suitable for a user to read aloud to support. The private key
+// is discarded; the signature only proves the bundle wasn't modified after
+// signing, not who signed it.
+func signManifest(raw []byte) (sig, pub []byte, fingerprint string) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		// crypto/rand failing means the system RNG is broken; there is no
+		// sane fallback, so surface it the same way a nil pointer would.
+		panic(fmt.Sprintf("debug bundle: generating signing key: %v", err))
+	}
+	sum := sha256.Sum256(pubKey)
+	return ed25519.Sign(privKey, raw), pubKey, hex.EncodeToString(sum[:8])
+}
+
+// writeManifest builds, signs, and writes the manifest and its signature
+// files into tmpDir, computing file hashes over everything collected so
+// far. It must run after every other collector has written its output and
+// before createArchive tars tmpDir up.
+func writeManifest(tmpDir string, collectors []CollectorResult, report *redact.Report) (fingerprint string, err error) {
+	files, err := hashTree(tmpDir)
+	if err != nil {
+		return "", fmt.Errorf("hashing bundle contents: %w", err)
+	}
+
+	manifest := BundleManifest{
+		Version:    VersionInfo{Version: version, Commit: commit, BuildDate: buildDate, GoVersion: goVersion(), OS: goos(), Arch: goarch()},
+		CreatedAt:  time.Now().UTC(),
+		NoRedact:   noRedact,
+		Collectors: collectors,
+		Files:      files,
+		Redaction:  report,
+	}
+
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encoding manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, manifestName), raw, 0644); err != nil {
+		return "", fmt.Errorf("writing manifest: %w", err)
+	}
+
+	sig, pub, fp := signManifest(raw)
+	if err := os.WriteFile(filepath.Join(tmpDir, sigName), sig, 0644); err != nil {
+		return "", fmt.Errorf("writing manifest signature: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, pubKeyName), pub, 0644); err != nil {
+		return "", fmt.Errorf("writing manifest public key: %w", err)
+	}
+
+	return fp, nil
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/roset-dev/roset/monorepo/cli/pkg/cli"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify <bundle.tar.gz>",
+	Short: "Check a diagnostic bundle's manifest signature and file integrity",
+	Long: `Verify re-hashes every file in a diagnostic bundle, compares the hashes
+against the bundle's manifest.json, and checks manifest.json.sig against the
+embedded manifest.pub. It does not establish who signed the bundle -
+runBundle's signing key is ephemeral and thrown away immediately - only that
+the archive matches the manifest it shipped with.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVerify,
+}
+
+func init() {
+	bundleCmd.AddCommand(verifyCmd)
+}
+
+// fileIntegrity is one row of the per-file verification table.
+type fileIntegrity struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	p := output.New(jsonOutput)
+	path := args[0]
+
+	entries, err := readArchive(path)
+	if err != nil {
+		return cli.SystemError(fmt.Errorf("reading bundle: %w", err))
+	}
+
+	rawManifest, ok := entries[manifestName]
+	if !ok {
+		return cli.UserError(fmt.Errorf("%s not found in bundle; this bundle predates signed manifests", manifestName))
+	}
+	sig, ok := entries[sigName]
+	if !ok {
+		return cli.UserError(fmt.Errorf("%s not found in bundle", sigName))
+	}
+	pub, ok := entries[pubKeyName]
+	if !ok {
+		return cli.UserError(fmt.Errorf("%s not found in bundle", pubKeyName))
+	}
+
+	if len(pub) != ed25519.PublicKeySize {
+		return cli.UserError(fmt.Errorf("%s is not a valid ed25519 public key", pubKeyName))
+	}
+	sigValid := ed25519.Verify(ed25519.PublicKey(pub), rawManifest, sig)
+
+	var manifest BundleManifest
+	if err := json.Unmarshal(rawManifest, &manifest); err != nil {
+		return cli.UserError(fmt.Errorf("parsing %s: %w", manifestName, err))
+	}
+
+	sum := sha256.Sum256(pub)
+	fingerprint := hex.EncodeToString(sum[:8])
+
+	rows := make([]fileIntegrity, 0, len(manifest.Files))
+	allOK := sigValid
+	for _, want := range manifest.Files {
+		got, ok := entries[want.Path]
+		status := "OK"
+		if !ok {
+			status = "MISSING"
+			allOK = false
+		} else {
+			sum := sha256.Sum256(got)
+			if hex.EncodeToString(sum[:]) != want.SHA256 {
+				status = "MODIFIED"
+				allOK = false
+			}
+		}
+		rows = append(rows, fileIntegrity{Path: want.Path, Status: status})
+	}
+
+	if jsonOutput {
+		p.PrintJSON(map[string]interface{}{
+			"signatureValid": sigValid,
+			"fingerprint":    fingerprint,
+			"manifest":       manifest,
+			"files":          rows,
+		})
+	} else {
+		if sigValid {
+			fmt.Printf("Signature:   valid (fingerprint %s)\n", fingerprint)
+		} else {
+			fmt.Printf("Signature:   INVALID (fingerprint %s)\n", fingerprint)
+		}
+		fmt.Printf("Created:     %s (roset %s)\n", manifest.CreatedAt.Format(time.RFC3339), manifest.Version.Version)
+		fmt.Printf("No-redact:   %v\n", manifest.NoRedact)
+		fmt.Println()
+
+		tableRows := make([][]string, 0, len(rows))
+		for _, r := range rows {
+			tableRows = append(tableRows, []string{r.Path, r.Status})
+		}
+		p.PrintTable([]string{"FILE", "INTEGRITY"}, tableRows)
+
+		if manifest.Redaction != nil {
+			fmt.Println()
+			fmt.Printf("Redaction:   %d total (%d high-entropy)\n", manifest.Redaction.TotalRedactions, manifest.Redaction.EntropyMatches)
+			for rule, count := range manifest.Redaction.ByRule {
+				fmt.Printf("  %-20s %d\n", rule, count)
+			}
+		}
+	}
+
+	if !allOK {
+		return cli.UserError(fmt.Errorf("bundle failed verification: signature valid=%v", sigValid))
+	}
+	return nil
+}
+
+// readArchive extracts every regular file in a roset-debug tar.gz into
+// memory, keyed by its path relative to the bundle root (i.e. with the
+// leading "roset-debug/" stripped), the same layout createArchive wrote.
+func readArchive(path string) (map[string][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	entries := make(map[string][]byte)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		name := hdr.Name
+		if rel, ok := stripRosetDebugPrefix(name); ok {
+			name = rel
+		}
+		entries[name] = data
+	}
+	return entries, nil
+}
+
+func stripRosetDebugPrefix(name string) (string, bool) {
+	const prefix = "roset-debug/"
+	if len(name) > len(prefix) && name[:len(prefix)] == prefix {
+		return name[len(prefix):], true
+	}
+	return "", false
+}
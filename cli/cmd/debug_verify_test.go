@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// buildBundle writes a fixture file tree, signs a manifest over it, and
+// archives it exactly the way runBundle does, returning the archive path.
+func buildBundle(t *testing.T, fixture map[string]string) string {
+	t.Helper()
+
+	srcDir := t.TempDir()
+	for name, content := range fixture {
+		if err := os.WriteFile(filepath.Join(srcDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("writing fixture file %s: %v", name, err)
+		}
+	}
+	if _, err := writeManifest(srcDir, nil, nil); err != nil {
+		t.Fatalf("writeManifest: %v", err)
+	}
+
+	archivePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := createArchive(srcDir, archivePath); err != nil {
+		t.Fatalf("createArchive: %v", err)
+	}
+	return archivePath
+}
+
+func TestVerify_RoundTrip_UnmodifiedBundlePasses(t *testing.T) {
+	archivePath := buildBundle(t, map[string]string{"notes.txt": "hello"})
+
+	jsonOutput = false
+	if err := runVerify(nil, []string{archivePath}); err != nil {
+		t.Fatalf("expected a freshly-signed, untampered bundle to verify, got: %v", err)
+	}
+}
+
+func TestVerify_DetectsModifiedFile(t *testing.T) {
+	srcDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(srcDir, "notes.txt"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := writeManifest(srcDir, nil, nil); err != nil {
+		t.Fatalf("writeManifest: %v", err)
+	}
+
+	// Tamper with the file's content after the manifest has hashed it, but
+	// before archiving - the archive will ship a file whose hash no longer
+	// matches what the signed manifest recorded.
+	if err := os.WriteFile(filepath.Join(srcDir, "notes.txt"), []byte("tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	archivePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := createArchive(srcDir, archivePath); err != nil {
+		t.Fatalf("createArchive: %v", err)
+	}
+
+	entries, err := readArchive(archivePath)
+	if err != nil {
+		t.Fatalf("readArchive: %v", err)
+	}
+	if string(entries["notes.txt"]) != "tampered" {
+		t.Fatalf("fixture setup broken: archive doesn't contain the tampered content")
+	}
+
+	jsonOutput = false
+	if err := runVerify(nil, []string{archivePath}); err == nil {
+		t.Fatal("expected verification to fail for a bundle with a modified file")
+	}
+}
+
+func TestVerify_RejectsBadSignature(t *testing.T) {
+	srcDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(srcDir, "notes.txt"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := writeManifest(srcDir, nil, nil); err != nil {
+		t.Fatalf("writeManifest: %v", err)
+	}
+
+	// Flip a byte in the signature file so it no longer verifies against
+	// the untouched manifest.json it was supposed to cover.
+	sigPath := filepath.Join(srcDir, sigName)
+	sig, err := os.ReadFile(sigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig[0] ^= 0xFF
+	if err := os.WriteFile(sigPath, sig, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	archivePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := createArchive(srcDir, archivePath); err != nil {
+		t.Fatalf("createArchive: %v", err)
+	}
+
+	jsonOutput = false
+	if err := runVerify(nil, []string{archivePath}); err == nil {
+		t.Fatal("expected verification to fail for a bundle with a corrupted signature")
+	}
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/roset-dev/roset/monorepo/cli/pkg/config"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/keychain"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/redact"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -16,17 +18,11 @@ var doctorCmd = &cobra.Command{
 	Use:   "doctor",
 	Short: "Diagnose configuration issues",
 	Long:  `Check for common configuration problems including file permissions, environment overrides, and missing settings.`,
-	Run:   runDoctor,
+	RunE:  runDoctor,
 }
 
 func init() {
-	// Add as subcommand of a config group
-	configCmd := &cobra.Command{
-		Use:   "config",
-		Short: "Manage CLI configuration",
-	}
 	configCmd.AddCommand(doctorCmd)
-	rootCmd.AddCommand(configCmd)
 }
 
 // DoctorResult contains the diagnostic output.
@@ -34,6 +30,7 @@ type DoctorResult struct {
 	ConfigPath   string        `json:"configPath"`
 	ConfigExists bool          `json:"configExists"`
 	Permissions  string        `json:"permissions,omitempty"`
+	Profile      string        `json:"profile"`
 	Issues       []DoctorIssue `json:"issues"`
 	EnvOverrides []string      `json:"envOverrides,omitempty"`
 }
@@ -44,7 +41,7 @@ type DoctorIssue struct {
 	Message string `json:"message"`
 }
 
-func runDoctor(cmd *cobra.Command, args []string) {
+func runDoctor(cmd *cobra.Command, args []string) error {
 	success := lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
 	warning := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
 	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
@@ -56,6 +53,7 @@ func runDoctor(cmd *cobra.Command, args []string) {
 
 	result := DoctorResult{
 		ConfigPath: configPath,
+		Profile:    config.CurrentProfile(),
 		Issues:     []DoctorIssue{},
 	}
 
@@ -82,7 +80,7 @@ func runDoctor(cmd *cobra.Command, args []string) {
 	}
 
 	// Check for environment overrides
-	envVars := []string{"ROSET_API_KEY", "ROSET_API_URL", "ROSET_DEBUG"}
+	envVars := []string{"ROSET_API_KEY", "ROSET_API_URL", "ROSET_DEBUG", "ROSET_PROFILE"}
 	for _, env := range envVars {
 		if val := os.Getenv(env); val != "" {
 			result.EnvOverrides = append(result.EnvOverrides, env)
@@ -105,12 +103,45 @@ func runDoctor(cmd *cobra.Command, args []string) {
 		})
 	}
 
+	// Check the credential helper the API key is stored under, if any.
+	if helper := config.Cfg.CredentialHelper; helper != "" {
+		if !keychain.NewHelper(helper).Available() {
+			result.Issues = append(result.Issues, DoctorIssue{
+				Level:   "error",
+				Message: fmt.Sprintf("Configured credential helper %q is no longer on PATH; run 'roset login' again to re-save the API key.", helper),
+			})
+		}
+	} else if config.Cfg.APIKey != "" {
+		result.Issues = append(result.Issues, DoctorIssue{
+			Level:   "warn",
+			Message: "API key is stored in plaintext in config.yaml; no OS credential helper was available when it was saved.",
+		})
+	}
+
+	// Check custom redaction rules, mirroring the config.yaml permission check
+	if rulesPath := config.Cfg.RedactRulesPath; rulesPath != "" {
+		if info, err := os.Stat(rulesPath); err == nil {
+			if perm := info.Mode().Perm(); perm&0077 != 0 {
+				result.Issues = append(result.Issues, DoctorIssue{
+					Level:   "warn",
+					Message: fmt.Sprintf("Redaction rules file has insecure permissions (%04o): %s. Should be 0600.", perm, rulesPath),
+				})
+			}
+		}
+		if err := redact.LoadError(); err != nil {
+			result.Issues = append(result.Issues, DoctorIssue{
+				Level:   "error",
+				Message: fmt.Sprintf("Failed to load custom redaction rules: %v", err),
+			})
+		}
+	}
+
 	// JSON output
 	if jsonOutput {
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
 		_ = enc.Encode(result)
-		return
+		return nil
 	}
 
 	// Human output
@@ -119,6 +150,7 @@ func runDoctor(cmd *cobra.Command, args []string) {
 	fmt.Println()
 
 	fmt.Printf("%s %s\n", label.Render("Config Path:"), configPath)
+	fmt.Printf("%s %s\n", label.Render("Profile:"), result.Profile)
 	if result.ConfigExists {
 		fmt.Printf("%s %s %s\n", label.Render("Status:"), success.Render("● Found"), "("+result.Permissions+")")
 	} else {
@@ -153,4 +185,5 @@ func runDoctor(cmd *cobra.Command, args []string) {
 	}
 
 	fmt.Println()
+	return nil
 }
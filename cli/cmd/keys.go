@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/roset-dev/roset/monorepo/cli/pkg/api"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/cli"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/config"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage Roset API keys",
+	Long: `keys manages the lifecycle of API keys against the Roset control plane:
+creating, listing, inspecting, rotating, and revoking them. This is
+distinct from 'roset login'/'roset profile', which store an existing key
+locally - 'roset keys' is how the key itself comes to exist.`,
+}
+
+var keysListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API keys",
+	RunE:  runKeysList,
+}
+
+func init() {
+	keysCmd.AddCommand(keysListCmd)
+	cli.MarkManagement(keysCmd)
+	rootCmd.AddCommand(keysCmd)
+}
+
+// keyRow is one row of `roset keys list`.
+type keyRow struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	Scopes    string `json:"scopes"`
+	CreatedAt string `json:"createdAt"`
+	ExpiresAt string `json:"expiresAt,omitempty"`
+	Revoked   bool   `json:"revoked"`
+}
+
+func newKeysClient() *api.Client {
+	return api.NewClient(config.Cfg.APIURL, config.Cfg.APIKey).WithRefresh(config.RefreshFunc(config.CurrentProfile()))
+}
+
+func runKeysList(cmd *cobra.Command, args []string) error {
+	p := output.New(jsonOutput)
+	client := newKeysClient()
+
+	keys, err := client.ListKeys(cmd.Context())
+	if err != nil {
+		return keysAPIError(err)
+	}
+
+	rows := make([]keyRow, 0, len(keys))
+	for _, k := range keys {
+		rows = append(rows, keyRowFromAPIKey(k))
+	}
+
+	if jsonOutput {
+		p.PrintJSON(rows)
+		return nil
+	}
+
+	tableRows := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		revoked := "no"
+		if r.Revoked {
+			revoked = "yes"
+		}
+		tableRows = append(tableRows, []string{r.ID, r.Name, r.Role, r.Scopes, r.CreatedAt, r.ExpiresAt, revoked})
+	}
+	p.PrintTable([]string{"ID", "NAME", "ROLE", "SCOPES", "CREATED", "EXPIRES", "REVOKED"}, tableRows)
+	return nil
+}
+
+func keyRowFromAPIKey(k api.APIKey) keyRow {
+	row := keyRow{
+		ID:        k.ID,
+		Name:      k.Name,
+		Role:      k.Role,
+		CreatedAt: k.CreatedAt.Format("2006-01-02"),
+		Revoked:   k.Revoked,
+	}
+	for i, scope := range k.Scopes {
+		if i > 0 {
+			row.Scopes += ","
+		}
+		row.Scopes += scope
+	}
+	if k.ExpiresAt != nil {
+		row.ExpiresAt = k.ExpiresAt.Format("2006-01-02")
+	}
+	return row
+}
+
+// keysAPIError maps an API failure to the exit code Execute should use:
+// auth failures (bad/expired key) as AuthError, a tripped circuit breaker
+// or transport failure as SystemError, and anything else the API rejected
+// (bad key ID, malformed scope, ...) as UserError.
+func keysAPIError(err error) error {
+	apiErr, ok := err.(*api.APIError)
+	if !ok {
+		return cli.SystemError(err)
+	}
+	switch apiErr.Code {
+	case "UNAUTHORIZED", "FORBIDDEN":
+		return cli.AuthError(fmt.Errorf("%s", apiErr.Message))
+	case "CIRCUIT_OPEN":
+		return cli.SystemError(fmt.Errorf("%s", apiErr.Message))
+	default:
+		return cli.UserError(fmt.Errorf("%s", apiErr.Message))
+	}
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/roset-dev/roset/monorepo/cli/pkg/api"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/cli"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/config"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	keysCreateRole        string
+	keysCreateTTL         string
+	keysCreateScope       string
+	keysCreateSaveProfile string
+)
+
+var keysCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Provision a new API key",
+	Long: `Create provisions a new API key and prints its secret exactly once -
+the Roset API never returns it again after this response. Pass
+--save-profile to store it straight into a profile instead of having to
+copy-paste it into 'roset login'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runKeysCreate,
+}
+
+func init() {
+	keysCreateCmd.Flags().StringVar(&keysCreateRole, "role", "", "Role to grant the new key")
+	keysCreateCmd.Flags().StringVar(&keysCreateTTL, "ttl", "", "Key lifetime (e.g. 30d, 12h); empty means no expiry")
+	keysCreateCmd.Flags().StringVar(&keysCreateScope, "scope", "", "Comma-separated scopes (e.g. mounts:read,mounts:write)")
+	keysCreateCmd.Flags().StringVar(&keysCreateSaveProfile, "save-profile", "", "Save the new key into this profile instead of just printing it")
+	keysCmd.AddCommand(keysCreateCmd)
+}
+
+func runKeysCreate(cmd *cobra.Command, args []string) error {
+	req := api.CreateKeyRequest{
+		Name: args[0],
+		Role: keysCreateRole,
+		TTL:  keysCreateTTL,
+	}
+	if keysCreateScope != "" {
+		req.Scopes = strings.Split(keysCreateScope, ",")
+	}
+
+	key, err := newKeysClient().CreateKey(cmd.Context(), req)
+	if err != nil {
+		return keysAPIError(err)
+	}
+
+	if keysCreateSaveProfile != "" {
+		if err := config.SaveToProfile(keysCreateSaveProfile, config.Cfg.APIURL, key.Secret); err != nil {
+			return cli.SystemError(fmt.Errorf("saving new key to profile %q: %w", keysCreateSaveProfile, err))
+		}
+		output.New(jsonOutput).PrintSuccess(fmt.Sprintf("Created API key %q and saved it to profile %q.", key.ID, keysCreateSaveProfile))
+		return nil
+	}
+
+	if jsonOutput {
+		output.New(true).PrintJSON(key)
+		return nil
+	}
+
+	printNewSecret(key.Secret)
+	return nil
+}
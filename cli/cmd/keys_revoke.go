@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/roset-dev/roset/monorepo/cli/pkg/cli"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var keysRevokeYes bool
+
+var keysRevokeCmd = &cobra.Command{
+	Use:   "revoke <id>",
+	Short: "Permanently disable an API key",
+	Long: `Revoke disables id immediately; any client still using it starts
+getting 401s on its next request. This cannot be undone - create a new key
+(or 'roset keys rotate') if the workload needs to keep running.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runKeysRevoke,
+}
+
+func init() {
+	keysRevokeCmd.Flags().BoolVarP(&keysRevokeYes, "yes", "y", false, "Skip the confirmation prompt")
+	keysCmd.AddCommand(keysRevokeCmd)
+}
+
+func runKeysRevoke(cmd *cobra.Command, args []string) error {
+	id := args[0]
+
+	if !keysRevokeYes {
+		if !cli.Confirm(fmt.Sprintf("Revoke API key %q? This cannot be undone.", id)) {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	if err := newKeysClient().RevokeKey(cmd.Context(), id); err != nil {
+		return keysAPIError(err)
+	}
+
+	output.New(jsonOutput).PrintSuccess(fmt.Sprintf("Revoked API key %q.", id))
+	return nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/cli"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var keysRotateYes bool
+
+var keysRotateCmd = &cobra.Command{
+	Use:   "rotate <id>",
+	Short: "Replace an API key's secret, keeping the same ID/name/role/scopes",
+	Long: `Rotate invalidates id's current secret and issues a new one under the
+same key ID, name, role, and scopes. The old secret stops working
+immediately - roll it out to every consumer of this key before rotating, or
+use 'roset keys create' instead if you need an overlap period.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runKeysRotate,
+}
+
+func init() {
+	keysRotateCmd.Flags().BoolVarP(&keysRotateYes, "yes", "y", false, "Skip the confirmation prompt")
+	keysCmd.AddCommand(keysRotateCmd)
+}
+
+func runKeysRotate(cmd *cobra.Command, args []string) error {
+	id := args[0]
+
+	if !keysRotateYes {
+		if !cli.Confirm(fmt.Sprintf("Rotate API key %q? The current secret will stop working immediately.", id)) {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	key, err := newKeysClient().RotateKey(cmd.Context(), id)
+	if err != nil {
+		return keysAPIError(err)
+	}
+
+	if jsonOutput {
+		output.New(true).PrintJSON(key)
+		return nil
+	}
+
+	printNewSecret(key.Secret)
+	return nil
+}
+
+// printNewSecret renders a new API key's one-time secret with an
+// impossible-to-miss warning, shared by `keys create` and `keys rotate`
+// since the API returns a secret in exactly the same circumstance for both.
+func printNewSecret(secret string) {
+	color.Red("!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!")
+	color.Red("!! STORE THIS KEY NOW. It will not be shown again.         !!")
+	color.Red("!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!")
+	fmt.Println()
+	fmt.Println(secret)
+	fmt.Println()
+}
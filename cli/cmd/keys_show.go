@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var keysShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show a single API key's metadata",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runKeysShow,
+}
+
+func init() {
+	keysCmd.AddCommand(keysShowCmd)
+}
+
+func runKeysShow(cmd *cobra.Command, args []string) error {
+	p := output.New(jsonOutput)
+	client := newKeysClient()
+
+	key, err := client.ShowKey(cmd.Context(), args[0])
+	if err != nil {
+		return keysAPIError(err)
+	}
+
+	row := keyRowFromAPIKey(*key)
+	if jsonOutput {
+		p.PrintJSON(row)
+		return nil
+	}
+
+	label := "%-12s %s\n"
+	fmt.Printf(label, "ID:", row.ID)
+	fmt.Printf(label, "Name:", row.Name)
+	fmt.Printf(label, "Role:", row.Role)
+	fmt.Printf(label, "Scopes:", row.Scopes)
+	fmt.Printf(label, "Created:", row.CreatedAt)
+	if row.ExpiresAt != "" {
+		fmt.Printf(label, "Expires:", row.ExpiresAt)
+	}
+	fmt.Printf(label, "Revoked:", fmt.Sprintf("%v", row.Revoked))
+	return nil
+}
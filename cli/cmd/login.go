@@ -1,79 +1,267 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/roset-dev/roset/monorepo/cli/pkg/api"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/cli"
 	"github.com/roset-dev/roset/monorepo/cli/pkg/config"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/oauth"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
+// loginValidationBackoff is how long validateWithRetry/the login TUI wait
+// before each retry of a transient credential-validation failure: one
+// initial attempt plus len(loginValidationBackoff) retries.
+var loginValidationBackoff = []time.Duration{250 * time.Millisecond, 750 * time.Millisecond, 2 * time.Second}
+
+var loginProfile string
+var loginMethod string
+var loginAPIKey string
+var loginAPIKeyStdin bool
+var loginURL string
+var loginTimeout time.Duration
+
 var loginCmd = &cobra.Command{
 	Use:   "login",
 	Short: "Authenticate with the Roset API",
-	Run:   runLogin,
+	Long: `Authenticate with the Roset API and save the resulting API key.
+
+By default this targets (and switches to) the active profile. Pass
+--profile to save into a different profile instead, creating it if it
+doesn't already exist; see 'roset profile --help'.
+
+--method key (the default) prompts for a pasted API key. --method device
+instead runs the OAuth device-authorization grant: the CLI shows a short
+code to enter in a browser elsewhere, so a headless or remote machine
+never has to see a long-lived key at all.
+
+--api-key, --api-key-stdin, --url, or --json bypass the interactive prompt
+entirely for scripts and CI: the key is validated and saved synchronously
+and the result is printed as JSON, with a non-zero exit on failure. login also auto-detects a non-TTY stdin (e.g. "echo $KEY |
+roset login") and switches to --api-key-stdin behavior on its own, so it
+never hangs waiting for input that isn't coming.`,
+	RunE: runLogin,
 }
 
 func init() {
 	rootCmd.AddCommand(loginCmd)
+	loginCmd.Flags().StringVar(&loginProfile, "profile", "", "Profile to save credentials into (default: the active profile)")
+	loginCmd.Flags().StringVar(&loginMethod, "method", "key", `Authentication method: "key" (paste an API key) or "device" (OAuth device authorization)`)
+	loginCmd.Flags().StringVar(&loginAPIKey, "api-key", "", "API key to save non-interactively (for scripts/CI; skips the prompt)")
+	loginCmd.Flags().BoolVar(&loginAPIKeyStdin, "api-key-stdin", false, "Read the API key from stdin non-interactively")
+	loginCmd.Flags().StringVar(&loginURL, "url", "", "API URL to save (default: the profile's existing URL, or the default API URL for a new profile)")
+	loginCmd.Flags().DurationVar(&loginTimeout, "timeout", 0, "Timeout for a single credential-validation attempt (default: config's login_timeout_seconds, or 10s)")
 }
 
 type loginModel struct {
+	ctx        context.Context
 	textInput  textinput.Model
-	state      int // 0: URL, 1: Key, 2: Validating, 3: Done
+	state      int // 0: URL, 1: Key, 2: Validating, 3: Done, 4: Device flow
+	profile    string
 	url        string
 	key        string
+	timeout    time.Duration
 	err        error
 	validating bool
 	latency    string
+
+	// retryAttempt/retryTotal track an in-progress validation retry (state
+	// 2) so View can render "Retrying (2/4)..." instead of a frozen
+	// "Validating credentials...". retryAttempt is 0 before the first retry.
+	retryAttempt int
+	retryTotal   int
+
+	// method and the fields below it are only used by the device-flow path
+	// (--method device); they're zero values for the default pasted-key flow.
+	method       string
+	deviceCode   *oauth.DeviceCode
+	refreshToken string
+	expiresIn    int
 }
 
-func initialLoginModel() loginModel {
+func initialLoginModel(ctx context.Context, profile, method string, timeout time.Duration) loginModel {
 	ti := textinput.New()
-	ti.Placeholder = "ros_..."
 	ti.Focus()
 	ti.CharLimit = 156
 	ti.Width = 40
-	ti.EchoMode = textinput.EchoPassword
 
-	return loginModel{
+	url := config.DefaultAPIURL
+	entry, exists := config.ProfileEntryByName(profile)
+	if exists && entry.APIURL != "" {
+		url = entry.APIURL
+	}
+
+	m := loginModel{
+		ctx:       ctx,
 		textInput: ti,
-		state:     1, // Start at Key input
-		url:       "https://api.roset.dev",
+		profile:   profile,
+		url:       url,
+		method:    method,
+		timeout:   timeout,
+	}
+
+	if method == "device" {
+		// The device flow never needs a pasted key, so it skips states 0/1
+		// entirely and goes straight to requesting a device code.
+		m.state = 4
+		return m
+	}
+
+	// A brand-new profile has no URL of its own yet, so start at state 0
+	// (URL prompt) instead of jumping straight to the key prompt like an
+	// existing profile does.
+	if !exists {
+		m.state = 0
+		ti.Placeholder = url
+		ti.SetValue(url)
+	} else {
+		m.state = 1
+		ti.Placeholder = "ros_..."
+		ti.EchoMode = textinput.EchoPassword
 	}
+	m.textInput = ti
+
+	return m
 }
 
 func (m loginModel) Init() tea.Cmd {
+	if m.method == "device" {
+		return requestDeviceCode(m.ctx, m.url)
+	}
 	return textinput.Blink
 }
 
-// validateCredentials is a tea.Cmd that validates the API key
+// validateResult is the terminal outcome of credential validation, whether
+// it took one attempt or several.
 type validateResult struct {
 	success bool
 	latency string
 	err     error
 }
 
-func validateCredentials(url, key string) tea.Cmd {
+// validateRetryMsg reports that one validation attempt failed transiently
+// and another is coming after a backoff delay, so the TUI can show
+// "Retrying (attempt/total)..." instead of sitting frozen.
+type validateRetryMsg struct {
+	attempt int
+	total   int
+}
+
+// beginAttemptMsg fires once loginValidationBackoff[attempt-2] has elapsed,
+// triggering the next validation attempt.
+type beginAttemptMsg struct {
+	attempt int
+}
+
+// validateAttempt makes one bounded Whoami call against url/key, timing it
+// out after timeout so a dead network can't hang the caller indefinitely.
+func validateAttempt(ctx context.Context, url, key string, timeout time.Duration) (time.Duration, error) {
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	client := api.NewClient(url, key)
+	_, latency, err := client.Whoami(attemptCtx)
+	return latency, err
+}
+
+// isTransientValidationError reports whether a failed validation attempt
+// is worth retrying: a plain (non-APIError) error means doRequest already
+// exhausted its own transport-level retries (connection refused, 5xx,
+// i/o timeout, ...) or the attempt's own timeout fired, both transient from
+// here. 401/403 are the one case that retrying can't fix, so those fail
+// fast instead of burning the rest of the attempt budget.
+func isTransientValidationError(err error) bool {
+	apiErr, ok := err.(*api.APIError)
+	if !ok {
+		return true
+	}
+	return apiErr.Code != "UNAUTHORIZED" && apiErr.Code != "FORBIDDEN"
+}
+
+// validateCredentials is a tea.Cmd that makes the first validation
+// attempt; the Update loop chains further attempts via beginAttemptMsg/
+// validateRetryMsg if it fails transiently.
+func validateCredentials(ctx context.Context, url, key string, timeout time.Duration) tea.Cmd {
+	return attemptValidation(ctx, url, key, timeout, 1)
+}
+
+// attemptValidation is a tea.Cmd for one numbered validation attempt. It
+// returns a terminal validateResult on success, on a non-retryable error,
+// or once attempt exhausts len(loginValidationBackoff)+1 tries; otherwise
+// it returns validateRetryMsg so the Update loop can schedule the next one.
+func attemptValidation(ctx context.Context, url, key string, timeout time.Duration, attempt int) tea.Cmd {
 	return func() tea.Msg {
-		client := api.NewClient(url, key)
-		_, latency, err := client.Whoami()
+		latency, err := validateAttempt(ctx, url, key, timeout)
+		if err == nil {
+			return validateResult{success: true, latency: latency.Round(time.Millisecond).String()}
+		}
 
-		if err != nil {
-			return validateResult{
-				success: false,
-				err:     err,
-			}
+		total := len(loginValidationBackoff) + 1
+		if attempt >= total || !isTransientValidationError(err) {
+			return validateResult{success: false, err: err}
 		}
+		return validateRetryMsg{attempt: attempt, total: total}
+	}
+}
 
-		return validateResult{
-			success: true,
-			latency: latency.Round(1e6).String(), // Round to milliseconds
+// validateWithRetry is validateCredentials' non-interactive counterpart: a
+// synchronous loop over the same attempts/backoff, for `roset login
+// --api-key`/CI where there's no bubbletea Update loop to drive retries.
+func validateWithRetry(ctx context.Context, url, key string, timeout time.Duration) (time.Duration, error) {
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		latency, err := validateAttempt(ctx, url, key, timeout)
+		if err == nil {
+			return latency, nil
+		}
+		lastErr = err
+		if attempt > len(loginValidationBackoff) || !isTransientValidationError(err) {
+			return 0, lastErr
 		}
+		time.Sleep(loginValidationBackoff[attempt-1])
+	}
+}
+
+// deviceCodeResult carries the outcome of requestDeviceCode.
+type deviceCodeResult struct {
+	code *oauth.DeviceCode
+	err  error
+}
+
+// requestDeviceCode is a tea.Cmd that starts the device-authorization
+// grant, so the TUI can show the user a code to enter in a browser.
+func requestDeviceCode(ctx context.Context, url string) tea.Cmd {
+	return func() tea.Msg {
+		code, err := oauth.RequestDeviceCode(ctx, http.DefaultClient, url)
+		return deviceCodeResult{code: code, err: err}
+	}
+}
+
+// deviceTokenResult carries the outcome of pollDeviceToken.
+type deviceTokenResult struct {
+	token *oauth.Token
+	err   error
+}
+
+// pollDeviceToken is a tea.Cmd that blocks until the user finishes (or
+// rejects) authorization in their browser, the device code expires, or ctx
+// is canceled; see oauth.PollToken for the RFC 8628 polling backoff.
+func pollDeviceToken(ctx context.Context, url string, code *oauth.DeviceCode) tea.Cmd {
+	return func() tea.Msg {
+		token, err := oauth.PollToken(ctx, http.DefaultClient, url, code)
+		return deviceTokenResult{token: token, err: err}
 	}
 }
 
@@ -81,6 +269,40 @@ func (m loginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case validateRetryMsg:
+		m.retryAttempt = msg.attempt
+		m.retryTotal = msg.total
+		delay := loginValidationBackoff[msg.attempt-1]
+		next := msg.attempt + 1
+		return m, tea.Tick(delay, func(time.Time) tea.Msg {
+			return beginAttemptMsg{attempt: next}
+		})
+
+	case beginAttemptMsg:
+		return m, attemptValidation(m.ctx, m.url, m.key, m.timeout, msg.attempt)
+
+	case deviceCodeResult:
+		if msg.err != nil {
+			m.err = fmt.Errorf("requesting device code: %w", msg.err)
+			m.state = 3
+			return m, tea.Quit
+		}
+		m.deviceCode = msg.code
+		return m, pollDeviceToken(m.ctx, m.url, msg.code)
+
+	case deviceTokenResult:
+		if msg.err != nil {
+			m.err = fmt.Errorf("device authorization failed: %w", msg.err)
+			m.state = 3
+			return m, tea.Quit
+		}
+		m.key = msg.token.AccessToken
+		m.refreshToken = msg.token.RefreshToken
+		m.expiresIn = msg.token.ExpiresIn
+		m.state = 2
+		m.validating = true
+		return m, validateCredentials(m.ctx, m.url, m.key, m.timeout)
+
 	case validateResult:
 		m.validating = false
 		if !msg.success {
@@ -89,9 +311,18 @@ func (m loginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 
-		// Validation succeeded - save config
+		// Validation succeeded - save config. The device flow's access
+		// token expires, so it's saved with its refresh token via
+		// SaveDeviceToken instead of the plain SaveToProfile a pasted key
+		// uses.
 		m.latency = msg.latency
-		if err := config.Save(m.url, m.key); err != nil {
+		var err error
+		if m.method == "device" {
+			err = config.SaveDeviceToken(m.profile, m.url, m.key, m.refreshToken, m.expiresIn)
+		} else {
+			err = config.SaveToProfile(m.profile, m.url, m.key)
+		}
+		if err != nil {
 			m.err = err
 		}
 		m.state = 3
@@ -107,6 +338,16 @@ func (m loginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			switch m.state {
+			case 0:
+				url := strings.TrimSpace(m.textInput.Value())
+				if url != "" {
+					m.url = url
+				}
+				m.textInput.SetValue("")
+				m.textInput.Placeholder = "ros_..."
+				m.textInput.EchoMode = textinput.EchoPassword
+				m.state = 1
+				return m, nil
 			case 1:
 				m.key = m.textInput.Value()
 				if m.key == "" {
@@ -116,7 +357,7 @@ func (m loginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				m.state = 2
 				m.validating = true
-				return m, validateCredentials(m.url, m.key)
+				return m, validateCredentials(m.ctx, m.url, m.key, m.timeout)
 			}
 		}
 	}
@@ -132,6 +373,13 @@ func (m loginModel) View() string {
 	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 
 	switch m.state {
+	case 0:
+		return fmt.Sprintf(
+			"%s\n\n%s\n\n%s",
+			titleStyle.Render(fmt.Sprintf("New profile %q - enter the API URL:", m.profile)),
+			m.textInput.View(),
+			"(esc to quit)",
+		) + "\n"
 	case 1:
 		return fmt.Sprintf(
 			"%s\n\n%s\n\n%s",
@@ -140,6 +388,9 @@ func (m loginModel) View() string {
 			"(esc to quit)",
 		) + "\n"
 	case 2:
+		if m.retryAttempt > 0 {
+			return titleStyle.Render(fmt.Sprintf("Retrying (%d/%d)...", m.retryAttempt+1, m.retryTotal)) + "\n"
+		}
 		return titleStyle.Render("Validating credentials...") + "\n"
 	case 3:
 		if m.err != nil {
@@ -153,16 +404,140 @@ func (m loginModel) View() string {
 		return fmt.Sprintf(
 			"%s %s\n",
 			successStyle.Render("✔"),
-			successStyle.Render(fmt.Sprintf("Authenticated successfully (latency: %s)", m.latency)),
+			successStyle.Render(fmt.Sprintf("Authenticated successfully (profile: %s, latency: %s)", m.profile, m.latency)),
+		)
+	case 4:
+		if m.deviceCode == nil {
+			return titleStyle.Render("Requesting device code...") + "\n"
+		}
+		return fmt.Sprintf(
+			"%s\n\n%s\n\n%s %s\n\n%s\n",
+			titleStyle.Render("Authenticate in your browser"),
+			fmt.Sprintf("Open %s and enter the code below:", m.deviceCode.VerificationURI),
+			dimStyle.Render("Code:"),
+			successStyle.Render(m.deviceCode.UserCode),
+			dimStyle.Render("Waiting for authorization... (esc to quit)"),
 		)
 	}
 	return ""
 }
 
-func runLogin(cmd *cobra.Command, args []string) {
-	p := tea.NewProgram(initialLoginModel())
+func runLogin(cmd *cobra.Command, args []string) error {
+	profile := loginProfile
+	if profile == "" {
+		profile = config.CurrentProfile()
+	}
+
+	if loginMethod != "key" && loginMethod != "device" {
+		return cli.UserError(fmt.Errorf("--method must be \"key\" or \"device\", got %q", loginMethod))
+	}
+
+	nonInteractive := loginAPIKey != "" || loginAPIKeyStdin || loginURL != "" || jsonOutput
+	if !nonInteractive && !term.IsTerminal(int(os.Stdin.Fd())) {
+		// echo $KEY | roset login: no non-interactive flag was passed, but
+		// stdin isn't a terminal either, so there's nothing to drive the
+		// bubbletea prompts - read the key from stdin instead of hanging.
+		nonInteractive = true
+		loginAPIKeyStdin = true
+	}
+
+	timeout := config.LoginTimeout()
+	if cmd.Flags().Changed("timeout") {
+		timeout = loginTimeout
+	}
+
+	if nonInteractive {
+		return runNonInteractiveLogin(cmd.Context(), profile, timeout)
+	}
+
+	p := tea.NewProgram(initialLoginModel(cmd.Context(), profile, loginMethod, timeout))
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
+	return nil
+}
+
+// loginResult is the JSON shape a non-interactive login prints on success.
+type loginResult struct {
+	OK        bool   `json:"ok"`
+	LatencyMs int64  `json:"latency_ms,omitempty"`
+	Profile   string `json:"profile,omitempty"`
+}
+
+// nonInteractiveAPIKey resolves the API key a non-interactive login should
+// use: --api-key directly, or --api-key-stdin (explicit or auto-detected by
+// runLogin) reading and trimming a single key from stdin.
+func nonInteractiveAPIKey() (string, error) {
+	if loginAPIKey != "" {
+		return loginAPIKey, nil
+	}
+	if !loginAPIKeyStdin {
+		return "", fmt.Errorf("non-interactive login requires --api-key or --api-key-stdin")
+	}
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("reading API key from stdin: %w", err)
+	}
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return "", fmt.Errorf("no API key read from stdin")
+	}
+	return key, nil
+}
+
+// runNonInteractiveLogin validates and saves an API key without starting
+// the bubbletea program, so `roset login` works from a Dockerfile, GitHub
+// Actions step, or other non-TTY context that would otherwise hang on the
+// interactive prompt.
+func runNonInteractiveLogin(ctx context.Context, profile string, timeout time.Duration) error {
+	if loginMethod == "device" {
+		return cli.UserError(fmt.Errorf("--method device requires the interactive prompt; drop --api-key/--api-key-stdin/--url to use it"))
+	}
+
+	key, err := nonInteractiveAPIKey()
+	if err != nil {
+		return cli.UserError(err)
+	}
+
+	url := loginURL
+	if url == "" {
+		url = config.DefaultAPIURL
+		if entry, ok := config.ProfileEntryByName(profile); ok && entry.APIURL != "" {
+			url = entry.APIURL
+		}
+	}
+
+	latency, err := validateWithRetry(ctx, url, key, timeout)
+	if err != nil {
+		return loginAPIError(err)
+	}
+
+	if err := config.SaveToProfile(profile, url, key); err != nil {
+		return cli.SystemError(err)
+	}
+
+	result := loginResult{OK: true, LatencyMs: latency.Milliseconds(), Profile: profile}
+	output.New(true).PrintJSON(result)
+	return nil
+}
+
+// loginAPIError maps a non-interactive login's API failure to the exit
+// code Execute should use, mirroring keysAPIError: bad/expired key as
+// AuthError, a tripped circuit breaker or transport failure as SystemError,
+// anything else the API rejected as UserError.
+func loginAPIError(err error) error {
+	apiErr, ok := err.(*api.APIError)
+	if !ok {
+		return cli.SystemError(err)
+	}
+	switch apiErr.Code {
+	case "UNAUTHORIZED", "FORBIDDEN":
+		return cli.AuthError(fmt.Errorf("%s", apiErr.Message))
+	case "CIRCUIT_OPEN":
+		return cli.SystemError(fmt.Errorf("%s", apiErr.Message))
+	default:
+		return cli.UserError(fmt.Errorf("%s", apiErr.Message))
+	}
 }
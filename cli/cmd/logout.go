@@ -2,16 +2,16 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/config"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/log"
 	"github.com/spf13/cobra"
 )
 
 var logoutCmd = &cobra.Command{
 	Use:   "logout",
-	Short: "Remove stored credentials and configuration",
+	Short: "Remove stored credentials for the active profile",
 	Run:   runLogout,
 }
 
@@ -23,23 +23,18 @@ func runLogout(cmd *cobra.Command, args []string) {
 	success := lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
 	warning := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
 
-	home, err := os.UserHomeDir()
-	if err != nil {
-		fmt.Println(warning.Render("Error getting home directory: " + err.Error()))
-		return
-	}
-
-	configFile := filepath.Join(home, ".roset", "config.yaml")
-
-	if _, err := os.Stat(configFile); os.IsNotExist(err) {
+	if config.Cfg.APIKey == "" {
+		log.Debug("logout called with no stored credentials", "profile", config.CurrentProfile())
 		fmt.Println(warning.Render("No credentials found. Already logged out."))
 		return
 	}
 
-	if err := os.Remove(configFile); err != nil {
+	profile := config.CurrentProfile()
+	if err := config.Clear(); err != nil {
+		log.Error("failed to clear stored credentials", "profile", profile, "error", err)
 		fmt.Println(warning.Render("Error removing credentials: " + err.Error()))
 		return
 	}
 
-	fmt.Println(success.Render("✔ Successfully logged out. Credentials removed."))
+	fmt.Println(success.Render(fmt.Sprintf("✔ Successfully logged out of profile %q. Credentials removed.", profile)))
 }
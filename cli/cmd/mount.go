@@ -12,6 +12,7 @@ import (
 	"syscall"
 
 	"github.com/roset-dev/roset/monorepo/cli/pkg/config"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/log"
 	"github.com/spf13/cobra"
 )
 
@@ -51,6 +52,7 @@ func runMount(cmd *cobra.Command, args []string) error {
 	// Find roset-fuse binary
 	fusePath, err := exec.LookPath("roset-fuse")
 	if err != nil {
+		log.Debug("roset-fuse not found in PATH", "mountpoint", mountpoint)
 		return fmt.Errorf("roset-fuse binary not found in PATH. Please install it first.\n\nInstallation:\n  cd fuse && cargo build --release\n  sudo cp target/release/roset-fuse /usr/local/bin/")
 	}
 
@@ -92,8 +94,8 @@ func runMount(cmd *cobra.Command, args []string) error {
 	fuseArgs = append(fuseArgs, "--foreground")
 
 	fmt.Printf("Mounting %s...\n", mountpoint)
+	log.Info("mounting filesystem", "mountpoint", mountpoint, "api_url", url, "read_only", mountReadOnly, "mount_id", mountMountID)
 
-	// Execute roset-fuse (replaces current process)
 	// Execute roset-fuse (replaces current process)
 	// TODO: syscall.Exec is not supported on Windows. Use exec.Command + wait for Windows support.
 	return syscall.Exec(fusePath, fuseArgs, os.Environ())
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"github.com/roset-dev/roset/monorepo/cli/pkg/cli"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/config"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var profileCmd = &cobra.Command{
+	Use:     "profile",
+	Aliases: []string{"context"},
+	Short:   "Manage named Roset credential profiles",
+	Long: `Profiles let the CLI hold more than one Roset API URL/key pair at once
+(e.g. "default" and "staging") and switch between them with 'roset profile
+use'. 'roset login' always saves into the active profile unless --profile
+names a different one. Also available as 'roset context', for anyone
+coming from docker/kubectl muscle memory.`,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured profiles",
+	Run:   runProfileList,
+}
+
+func init() {
+	profileCmd.AddCommand(profileListCmd)
+	cli.MarkManagement(profileCmd)
+	rootCmd.AddCommand(profileCmd)
+}
+
+// profileRow is one row of `roset profile list`.
+type profileRow struct {
+	Name      string `json:"name"`
+	APIURL    string `json:"apiUrl"`
+	HasAPIKey bool   `json:"hasApiKey"`
+	Active    bool   `json:"active"`
+}
+
+func runProfileList(cmd *cobra.Command, args []string) {
+	p := output.New(jsonOutput)
+	active := config.CurrentProfile()
+
+	rows := make([]profileRow, 0, len(config.ProfileNames()))
+	for _, name := range config.ProfileNames() {
+		entry, _ := config.ProfileEntryByName(name)
+		rows = append(rows, profileRow{
+			Name:      name,
+			APIURL:    entry.APIURL,
+			HasAPIKey: entry.APIKey != "",
+			Active:    name == active,
+		})
+	}
+
+	if jsonOutput {
+		p.PrintJSON(rows)
+		return
+	}
+
+	tableRows := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		name := r.Name
+		if r.Active {
+			name = "* " + name
+		}
+		hasKey := "no"
+		if r.HasAPIKey {
+			hasKey = "yes"
+		}
+		tableRows = append(tableRows, []string{name, r.APIURL, hasKey})
+	}
+	p.PrintTable([]string{"NAME", "API URL", "HAS KEY"}, tableRows)
+}
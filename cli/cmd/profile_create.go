@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/roset-dev/roset/monorepo/cli/pkg/cli"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/config"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var profileCreateAPIURL string
+
+var profileCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a new, credential-less profile",
+	Long: `Create adds a new profile with no stored API key. Run
+'roset login --profile <name>' afterwards to authenticate it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileCreate,
+}
+
+func init() {
+	profileCreateCmd.Flags().StringVar(&profileCreateAPIURL, "api-url", "", "API URL for the new profile (default: "+config.DefaultAPIURL+")")
+	profileCmd.AddCommand(profileCreateCmd)
+}
+
+func runProfileCreate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if err := config.CreateProfile(name, profileCreateAPIURL); err != nil {
+		return cli.UserError(err)
+	}
+
+	output.New(jsonOutput).PrintSuccess(fmt.Sprintf("Created profile %q. Run 'roset login --profile %s' to authenticate it.", name, name))
+	return nil
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/roset-dev/roset/monorepo/cli/pkg/cli"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/config"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var profileRemoveYes bool
+
+var profileRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a profile and its stored credential",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runProfileRemove,
+}
+
+func init() {
+	profileRemoveCmd.Flags().BoolVarP(&profileRemoveYes, "yes", "y", false, "Skip the confirmation prompt")
+	profileCmd.AddCommand(profileRemoveCmd)
+}
+
+func runProfileRemove(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	if !profileRemoveYes {
+		if !cli.Confirm(fmt.Sprintf("Remove profile %q? This also erases its stored credential.", name)) {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	if err := config.RemoveProfile(name); err != nil {
+		return cli.UserError(err)
+	}
+
+	output.New(jsonOutput).PrintSuccess(fmt.Sprintf("Removed profile %q.", name))
+	return nil
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/roset-dev/roset/monorepo/cli/pkg/cli"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/config"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var profileShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show a single profile's settings",
+	Long:  `Show prints one profile's API URL and whether it has a stored key. It defaults to the active profile when no name is given.`,
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runProfileShow,
+}
+
+func init() {
+	profileCmd.AddCommand(profileShowCmd)
+}
+
+func runProfileShow(cmd *cobra.Command, args []string) error {
+	name := config.CurrentProfile()
+	if len(args) == 1 {
+		name = args[0]
+	}
+
+	entry, ok := config.ProfileEntryByName(name)
+	if !ok {
+		return cli.UserError(fmt.Errorf("profile %q does not exist", name))
+	}
+
+	row := profileRow{
+		Name:      name,
+		APIURL:    entry.APIURL,
+		HasAPIKey: entry.APIKey != "",
+		Active:    name == config.CurrentProfile(),
+	}
+
+	p := output.New(jsonOutput)
+	if jsonOutput {
+		p.PrintJSON(row)
+		return nil
+	}
+
+	label := "%-14s %s\n"
+	fmt.Printf(label, "Name:", row.Name)
+	fmt.Printf(label, "API URL:", row.APIURL)
+	fmt.Printf(label, "Has API Key:", fmt.Sprintf("%v", row.HasAPIKey))
+	fmt.Printf(label, "Active:", fmt.Sprintf("%v", row.Active))
+	if entry.CredentialHelper != "" {
+		fmt.Printf(label, "Credential Helper:", entry.CredentialHelper)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/roset-dev/roset/monorepo/cli/pkg/cli"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/config"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Switch the active profile",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runProfileUse,
+}
+
+func init() {
+	profileCmd.AddCommand(profileUseCmd)
+}
+
+func runProfileUse(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if err := config.UseProfile(name); err != nil {
+		return cli.UserError(err)
+	}
+
+	output.New(jsonOutput).PrintSuccess(fmt.Sprintf("Switched to profile %q.", name))
+	return nil
+}
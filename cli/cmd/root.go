@@ -1,12 +1,19 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/cli"
 	"github.com/roset-dev/roset/monorepo/cli/pkg/config"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/log"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/redact"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/telemetry"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -14,8 +21,13 @@ var (
 	verbose    bool
 	apiURL     string
 	apiKey     string
+	profile    string
 )
 
+// shutdownTelemetry flushes and closes the tracer configured by
+// telemetry.Init; it's a no-op until initConfig runs.
+var shutdownTelemetry = func(context.Context) error { return nil }
+
 var banner = `
     ____  ____  _____ ______ ______ 
    / __ \/ __ \/ ___// ____//_  __/ 
@@ -32,25 +44,66 @@ var rootCmd = &cobra.Command{
 		printBanner()
 		_ = cmd.Help()
 	},
+	PersistentPreRunE:  startRootSpan,
+	PersistentPostRunE: stopRootSpan,
+}
+
+// startRootSpan opens a span named after the command that's actually being
+// run (not just "roset"), so a trace for `roset status` reads as "status"
+// rather than every command looking identical in a trace backend.
+func startRootSpan(cmd *cobra.Command, args []string) error {
+	ctx, _ := telemetry.Tracer().Start(cmd.Context(), cmd.Name())
+	cmd.SetContext(ctx)
+	return nil
 }
 
-func Execute() error {
-	return rootCmd.Execute()
+// stopRootSpan ends the span opened by startRootSpan and shuts the tracer
+// down, flushing any spans it recorded (the HTTP attempt spans api.Client
+// creates as children of it included).
+func stopRootSpan(cmd *cobra.Command, args []string) error {
+	trace.SpanFromContext(cmd.Context()).End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTelemetry(ctx); err != nil {
+		log.Warn("failed to shut down telemetry", "error", err)
+	}
+	return nil
+}
+
+// Execute runs the CLI and exits the process with the exit code matching
+// whatever error (if any) bubbled up from the command that ran; see
+// pkg/cli.Execute for how that mapping works.
+func Execute() {
+	cli.Execute(rootCmd)
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
+	cli.SetupRootCommand(rootCmd)
 
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
 	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose logging")
 	rootCmd.PersistentFlags().StringVar(&apiURL, "api-url", "", "Roset API URL (overrides config)")
 	rootCmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "Roset API Key (overrides config)")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "Profile to use for this command (overrides ROSET_PROFILE and config.yaml)")
 }
 
 func initConfig() {
+	log.Init(verbose, jsonOutput)
+	shutdownTelemetry = telemetry.Init(version)
+
 	if err := config.Init(); err != nil {
 		fmt.Fprintf(os.Stderr, "Config error: %v\n", err)
 	}
+	if err := config.Resolve(apiKey, apiURL, profile, verbose); err != nil {
+		fmt.Fprintf(os.Stderr, "Config error: %v\n", err)
+	}
+	// Non-fatal: a bad rules file falls back to built-in patterns, and
+	// `roset config doctor` surfaces the parse error in detail.
+	if err := redact.LoadUserRules(config.Cfg.RedactRulesPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
 }
 
 func printBanner() {
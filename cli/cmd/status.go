@@ -11,6 +11,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/roset-dev/roset/monorepo/cli/pkg/api"
 	"github.com/roset-dev/roset/monorepo/cli/pkg/config"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/log"
 	"github.com/spf13/cobra"
 )
 
@@ -33,6 +34,7 @@ type StatusOutput struct {
 }
 
 type ConfigStatus struct {
+	Profile   string `json:"profile"`
 	APIURL    string `json:"apiUrl"`
 	HasAPIKey bool   `json:"hasApiKey"`
 }
@@ -40,6 +42,7 @@ type ConfigStatus struct {
 type ConnStatus struct {
 	Reachable     bool   `json:"reachable"`
 	Authenticated bool   `json:"authenticated"`
+	Degraded      bool   `json:"degraded,omitempty"`
 	LatencyMs     int64  `json:"latencyMs,omitempty"`
 	Error         string `json:"error,omitempty"`
 }
@@ -60,6 +63,7 @@ func runStatus(cmd *cobra.Command, args []string) {
 
 	output := StatusOutput{
 		Config: ConfigStatus{
+			Profile:   config.CurrentProfile(),
 			APIURL:    config.Cfg.APIURL,
 			HasAPIKey: config.Cfg.APIKey != "",
 		},
@@ -73,23 +77,30 @@ func runStatus(cmd *cobra.Command, args []string) {
 
 	// Check API connectivity and auth
 	if config.Cfg.APIKey != "" {
-		client := api.NewClient(config.Cfg.APIURL, config.Cfg.APIKey)
-		_, latency, err := client.Whoami()
+		log.Debug("checking API connectivity", "profile", output.Config.Profile, "api_url", config.Cfg.APIURL)
+		client := api.NewClient(config.Cfg.APIURL, config.Cfg.APIKey).WithRefresh(config.RefreshFunc(config.CurrentProfile()))
+		_, latency, err := client.Whoami(cmd.Context())
 		output.Connection.LatencyMs = latency.Milliseconds()
 
 		if err == nil {
 			output.Connection.Reachable = true
 			output.Connection.Authenticated = true
+		} else if apiErr, ok := err.(*api.APIError); ok && apiErr.Code == "CIRCUIT_OPEN" {
+			// The breaker tripped before a request went out at all; report
+			// degraded rather than unreachable, since we don't actually
+			// know the API is down, only that it recently failed a lot.
+			output.Connection.Degraded = true
+			output.Connection.Error = apiErr.Message
+		} else if apiErr, ok := err.(*api.APIError); ok {
+			// Any other API error means the API is reachable, just not
+			// authenticating this request.
+			output.Connection.Reachable = true
+			output.Connection.Authenticated = false
+			output.Connection.Error = apiErr.Message
 		} else {
-			// Check if it's just auth error (API is reachable)
-			if apiErr, ok := err.(*api.APIError); ok {
-				output.Connection.Reachable = true
-				output.Connection.Authenticated = false
-				output.Connection.Error = apiErr.Message
-			} else {
-				output.Connection.Reachable = false
-				output.Connection.Error = err.Error()
-			}
+			output.Connection.Reachable = false
+			output.Connection.Error = err.Error()
+			log.Warn("API unreachable", "api_url", config.Cfg.APIURL, "error", err)
 		}
 	}
 
@@ -107,6 +118,7 @@ func runStatus(cmd *cobra.Command, args []string) {
 	fmt.Println(strings.Repeat("─", 40))
 
 	// Configuration
+	fmt.Printf("%s %s\n", label.Render("Profile:"), output.Config.Profile)
 	fmt.Printf("%s %s\n", label.Render("API URL:"), config.Cfg.APIURL)
 	if config.Cfg.APIKey != "" {
 		masked := maskKey(config.Cfg.APIKey)
@@ -117,7 +129,12 @@ func runStatus(cmd *cobra.Command, args []string) {
 
 	// Connectivity
 	fmt.Println()
-	if output.Connection.Reachable {
+	if output.Connection.Degraded {
+		fmt.Printf("%s %s\n",
+			label.Render("API Status:"),
+			warning.Render("● Degraded: "+output.Connection.Error),
+		)
+	} else if output.Connection.Reachable {
 		if output.Connection.Authenticated {
 			fmt.Printf("%s %s %s\n",
 				label.Render("API Status:"),
@@ -29,7 +29,7 @@ type VersionInfo struct {
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print the CLI version information",
-	Run:   runVersion,
+	RunE:  runVersion,
 }
 
 func init() {
@@ -55,7 +55,7 @@ func init() {
 	}
 }
 
-func runVersion(cmd *cobra.Command, args []string) {
+func runVersion(cmd *cobra.Command, args []string) error {
 	info := VersionInfo{
 		Version:   version,
 		Commit:    commit,
@@ -69,7 +69,7 @@ func runVersion(cmd *cobra.Command, args []string) {
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
 		_ = enc.Encode(info)
-		return
+		return nil
 	}
 
 	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#4DA3FF"))
@@ -82,6 +82,7 @@ func runVersion(cmd *cobra.Command, args []string) {
 	fmt.Printf("%s %s\n", label.Render("Commit:"), commit)
 	fmt.Printf("%s %s\n", label.Render("Built:"), buildDate)
 	fmt.Println()
+	return nil
 }
 
 func goVersion() string {
@@ -0,0 +1,95 @@
+// Package analyzer turns a detected (or user-supplied) credential into a
+// report of what that credential can actually do against its live provider.
+// It is the second phase of the secret-handling story started by pkg/redact:
+// where redact answers "is this a secret and what kind", analyzer answers
+// "what can this secret reach".
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ResourceKind classifies the principal a credential resolves to.
+type ResourceKind string
+
+const (
+	ResourceUser           ResourceKind = "user"
+	ResourceToken          ResourceKind = "token"
+	ResourceServiceAccount ResourceKind = "service_account"
+	ResourceUnknown        ResourceKind = "unknown"
+)
+
+// Access describes whether a probed action succeeded.
+type Access string
+
+const (
+	AccessYes     Access = "yes"
+	AccessNo      Access = "no"
+	AccessUnknown Access = "unknown"
+)
+
+// Permission is one row of the permissions matrix reported back to the user.
+type Permission struct {
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+	Access   Access `json:"access"`
+}
+
+// Result is the normalized output of analyzing a credential, regardless of
+// which provider it came from.
+type Result struct {
+	Type         string       `json:"type"`
+	ResourceKind ResourceKind `json:"resourceKind"`
+	Principal    string       `json:"principal,omitempty"`
+	Permissions  []Permission `json:"permissions,omitempty"`
+	Expiry       *time.Time   `json:"expiry,omitempty"`
+	Notes        []string     `json:"notes,omitempty"`
+}
+
+// Analyzer probes a live provider to explain what a credential can do.
+// Implementations must tolerate a context deadline/cancellation and should
+// return a partial Result plus a note rather than an error when only some
+// probes fail (e.g. scopes known but a permission check was denied).
+type Analyzer interface {
+	// Type returns the redact.Classify pattern name this analyzer handles,
+	// e.g. "aws_access_key" or "jwt".
+	Type() string
+	Analyze(ctx context.Context, secret string) (*Result, error)
+}
+
+var registry = map[string]Analyzer{}
+
+// Register adds an Analyzer to the default registry, keyed by its Type().
+// Built-in analyzers call this from an init() func; a duplicate Type panics
+// since it indicates a programming error, not user input.
+func Register(a Analyzer) {
+	t := a.Type()
+	if _, exists := registry[t]; exists {
+		panic(fmt.Sprintf("analyzer: duplicate registration for type %q", t))
+	}
+	registry[t] = a
+}
+
+// For looks up the analyzer registered for a redact.Classify type name.
+func For(redactType string) (Analyzer, bool) {
+	a, ok := registry[redactType]
+	return a, ok
+}
+
+// offlineKey is unexported so only WithOffline/IsOffline can set or read it.
+type offlineKey struct{}
+
+// WithOffline marks the context as offline-only: analyzers must not make
+// network calls and should limit themselves to decoding/static inspection
+// of the secret itself (e.g. JWT header/payload without introspection).
+func WithOffline(ctx context.Context, offline bool) context.Context {
+	return context.WithValue(ctx, offlineKey{}, offline)
+}
+
+// IsOffline reports whether the context was marked offline via WithOffline.
+func IsOffline(ctx context.Context) bool {
+	offline, _ := ctx.Value(offlineKey{}).(bool)
+	return offline
+}
@@ -0,0 +1,282 @@
+package analyzer
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register(&awsAnalyzer{
+		region:      "us-east-1",
+		client:      http.DefaultClient,
+		stsEndpoint: "https://sts.amazonaws.com/",
+		iamEndpoint: "https://iam.amazonaws.com/",
+	})
+}
+
+// awsBaselineActions is a small, representative set of read/write actions
+// used to build a baseline scope matrix via IAM SimulatePrincipalPolicy.
+// It is intentionally not exhaustive: the goal is "can this credential read
+// S3, can it touch IAM itself, can it spin up compute", not a full audit.
+var awsBaselineActions = []struct {
+	resource, action string
+}{
+	{"s3", "s3:ListAllMyBuckets"},
+	{"s3", "s3:GetObject"},
+	{"ec2", "ec2:DescribeInstances"},
+	{"iam", "iam:ListUsers"},
+	{"iam", "iam:CreateAccessKey"},
+	{"sts", "sts:AssumeRole"},
+}
+
+// awsAnalyzer calls STS GetCallerIdentity to identify the principal behind an
+// AWS access key, then IAM SimulatePrincipalPolicy to sketch a baseline scope
+// matrix. Both calls are authenticated with the credential under analysis, so
+// the secret string must be "<accessKeyId>:<secretAccessKey>"; a bare access
+// key ID (no secret) can't be signed against AWS and is reported as such.
+type awsAnalyzer struct {
+	region string
+	client *http.Client
+
+	// stsEndpoint/iamEndpoint are overridden by tests to point at an
+	// httptest.Server instead of the real AWS endpoints.
+	stsEndpoint string
+	iamEndpoint string
+}
+
+func (a *awsAnalyzer) Type() string { return "aws_access_key" }
+
+func (a *awsAnalyzer) Analyze(ctx context.Context, secret string) (*Result, error) {
+	accessKeyID, secretAccessKey, ok := strings.Cut(secret, ":")
+	if !ok || secretAccessKey == "" {
+		return &Result{
+			Type:         "aws_access_key",
+			ResourceKind: ResourceUnknown,
+			Principal:    accessKeyID,
+			Notes:        []string{"only an access key ID was provided; pass \"<accessKeyId>:<secretAccessKey>\" to call STS and get a live report"},
+		}, nil
+	}
+
+	if IsOffline(ctx) {
+		return &Result{
+			Type:         "aws_access_key",
+			ResourceKind: ResourceUnknown,
+			Principal:    accessKeyID,
+			Notes:        []string{"offline mode: AWS access keys carry no decodable claims, nothing to report without calling STS"},
+		}, nil
+	}
+
+	creds := awsCredentials{accessKeyID: accessKeyID, secretAccessKey: secretAccessKey, region: a.region}
+
+	arn, accountID, err := a.getCallerIdentity(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Type:         "aws_access_key",
+		ResourceKind: resourceKindForARN(arn),
+		Principal:    arn,
+		Notes:        []string{"account=" + accountID},
+	}
+
+	result.Permissions = a.simulateBaseline(ctx, creds, arn)
+	return result, nil
+}
+
+func resourceKindForARN(arn string) ResourceKind {
+	switch {
+	case strings.Contains(arn, ":user/"):
+		return ResourceUser
+	case strings.Contains(arn, ":role/"), strings.Contains(arn, ":assumed-role/"):
+		return ResourceServiceAccount
+	default:
+		return ResourceUnknown
+	}
+}
+
+func (a *awsAnalyzer) getCallerIdentity(ctx context.Context, creds awsCredentials) (arn, accountID string, err error) {
+	body, err := a.call(ctx, creds, "sts", a.stsEndpoint, url.Values{
+		"Action":  {"GetCallerIdentity"},
+		"Version": {"2011-06-15"},
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	var parsed struct {
+		GetCallerIdentityResult struct {
+			Arn     string `xml:"Arn"`
+			Account string `xml:"Account"`
+		} `xml:"GetCallerIdentityResult"`
+	}
+	if err := xml.Unmarshal(body, &parsed); err != nil {
+		return "", "", fmt.Errorf("parsing STS response: %w", err)
+	}
+	if parsed.GetCallerIdentityResult.Arn == "" {
+		return "", "", fmt.Errorf("STS GetCallerIdentity returned no ARN (invalid or revoked credentials?)")
+	}
+
+	return parsed.GetCallerIdentityResult.Arn, parsed.GetCallerIdentityResult.Account, nil
+}
+
+func (a *awsAnalyzer) simulateBaseline(ctx context.Context, creds awsCredentials, arn string) []Permission {
+	perms := make([]Permission, 0, len(awsBaselineActions))
+	for _, probe := range awsBaselineActions {
+		access := a.simulateOne(ctx, creds, arn, probe.action)
+		perms = append(perms, Permission{Resource: probe.resource, Action: probe.action, Access: access})
+	}
+	return perms
+}
+
+func (a *awsAnalyzer) simulateOne(ctx context.Context, creds awsCredentials, arn, action string) Access {
+	body, err := a.call(ctx, creds, "iam", a.iamEndpoint, url.Values{
+		"Action":          {"SimulatePrincipalPolicy"},
+		"Version":         {"2010-05-08"},
+		"PolicySourceArn": {arn},
+		"ActionNames.member.1": {action},
+	})
+	if err != nil {
+		// SimulatePrincipalPolicy itself requires iam:SimulatePrincipalPolicy;
+		// a denial here just means we can't determine the answer, not that
+		// the underlying action is denied.
+		return AccessUnknown
+	}
+
+	var parsed struct {
+		SimulatePrincipalPolicyResult struct {
+			EvaluationResults struct {
+				Member []struct {
+					EvalDecision string `xml:"EvalDecision"`
+				} `xml:"member"`
+			} `xml:"EvaluationResults"`
+		} `xml:"SimulatePrincipalPolicyResult"`
+	}
+	if err := xml.Unmarshal(body, &parsed); err != nil {
+		return AccessUnknown
+	}
+	if len(parsed.SimulatePrincipalPolicyResult.EvaluationResults.Member) == 0 {
+		return AccessUnknown
+	}
+
+	if parsed.SimulatePrincipalPolicyResult.EvaluationResults.Member[0].EvalDecision == "allowed" {
+		return AccessYes
+	}
+	return AccessNo
+}
+
+// call issues a SigV4-signed POST against a single-service AWS endpoint and
+// returns the raw response body, or an error on any non-2xx status.
+func (a *awsAnalyzer) call(ctx context.Context, creds awsCredentials, service, endpoint string, form url.Values) ([]byte, error) {
+	body := form.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	if err := signSigV4(req, creds, service, body); err != nil {
+		return nil, fmt.Errorf("signing AWS request: %w", err)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("AWS %s request failed: %w", service, err)
+	}
+	defer resp.Body.Close()
+
+	respBody := make([]byte, 0, 4096)
+	buf := make([]byte, 4096)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		respBody = append(respBody, buf[:n]...)
+		if readErr != nil {
+			break
+		}
+	}
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("AWS %s returned %d: %s", service, resp.StatusCode, string(respBody))
+	}
+
+	return respBody, nil
+}
+
+type awsCredentials struct {
+	accessKeyID     string
+	secretAccessKey string
+	region          string
+}
+
+// signSigV4 signs req in place using AWS Signature Version 4 for the given
+// service, following the canonical-request/string-to-sign/signing-key
+// recipe from the AWS documentation. It's deliberately minimal: POST with an
+// x-www-form-urlencoded body and no query string, which is all STS/IAM need.
+func signSigV4(req *http.Request, creds awsCredentials, service, body string) error {
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+
+	host := req.URL.Host
+	req.Header.Set("Host", host)
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "content-type;host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := fmt.Sprintf(
+		"content-type:%s\nhost:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\n",
+		req.Header.Get("Content-Type"), host, payloadHash, amzDate,
+	)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		"/",
+		"",
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	credentialScope := fmt.Sprintf("%s/%s/%s/aws4_request", dateStamp, creds.region, service)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		credentialScope,
+		sha256Hex(canonicalRequest),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+creds.secretAccessKey), dateStamp)
+	signingKey = hmacSHA256(signingKey, creds.region)
+	signingKey = hmacSHA256(signingKey, service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	authHeader := fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.accessKeyID, credentialScope, signedHeaders, signature,
+	)
+	req.Header.Set("Authorization", authHeader)
+
+	return nil
+}
+
+func sha256Hex(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
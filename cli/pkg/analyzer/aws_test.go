@@ -0,0 +1,170 @@
+package analyzer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAWSAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name          string
+		secret        string
+		offline       bool
+		sts           http.HandlerFunc
+		iam           http.HandlerFunc
+		wantErr       bool
+		wantPrincipal string
+		wantAccess    map[string]Access // action -> access, checked against Permissions
+	}{
+		{
+			name:          "access key ID only, no secret",
+			secret:        "AKIAEXAMPLE",
+			wantPrincipal: "AKIAEXAMPLE",
+		},
+		{
+			name:          "offline mode skips STS entirely",
+			secret:        "AKIAEXAMPLE:secret",
+			offline:       true,
+			wantPrincipal: "AKIAEXAMPLE",
+		},
+		{
+			name:   "user ARN with baseline permissions",
+			secret: "AKIAEXAMPLE:secret",
+			sts: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`<GetCallerIdentityResponse><GetCallerIdentityResult>
+					<Arn>arn:aws:iam::123456789012:user/alice</Arn>
+					<Account>123456789012</Account>
+				</GetCallerIdentityResult></GetCallerIdentityResponse>`))
+			},
+			iam: func(w http.ResponseWriter, r *http.Request) {
+				r.ParseForm()
+				action := r.Form.Get("ActionNames.member.1")
+				decision := "denied"
+				if action == "s3:ListAllMyBuckets" {
+					decision = "allowed"
+				}
+				w.Write([]byte(`<SimulatePrincipalPolicyResponse><SimulatePrincipalPolicyResult><EvaluationResults><member>
+					<EvalDecision>` + decision + `</EvalDecision>
+				</member></EvaluationResults></SimulatePrincipalPolicyResult></SimulatePrincipalPolicyResponse>`))
+			},
+			wantPrincipal: "arn:aws:iam::123456789012:user/alice",
+			wantAccess: map[string]Access{
+				"s3:ListAllMyBuckets": AccessYes,
+				"s3:GetObject":        AccessNo,
+			},
+		},
+		{
+			name:   "STS rejects the credentials",
+			secret: "AKIAEXAMPLE:secret",
+			sts: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			},
+			wantErr: true,
+		},
+		{
+			name:   "IAM denied falls back to unknown access",
+			secret: "AKIAEXAMPLE:secret",
+			sts: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`<GetCallerIdentityResponse><GetCallerIdentityResult>
+					<Arn>arn:aws:sts::123456789012:assumed-role/ci/session</Arn>
+					<Account>123456789012</Account>
+				</GetCallerIdentityResult></GetCallerIdentityResponse>`))
+			},
+			iam: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			},
+			wantPrincipal: "arn:aws:sts::123456789012:assumed-role/ci/session",
+			wantAccess: map[string]Access{
+				"s3:ListAllMyBuckets": AccessUnknown,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a := &awsAnalyzer{region: "us-east-1", client: http.DefaultClient}
+
+			if tc.sts != nil {
+				sts := httptest.NewServer(tc.sts)
+				defer sts.Close()
+				a.stsEndpoint = sts.URL
+			}
+			if tc.iam != nil {
+				iam := httptest.NewServer(tc.iam)
+				defer iam.Close()
+				a.iamEndpoint = iam.URL
+			}
+
+			ctx := WithOffline(context.Background(), tc.offline)
+			result, err := a.Analyze(ctx, tc.secret)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got result %+v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Principal != tc.wantPrincipal {
+				t.Errorf("Principal = %q, want %q", result.Principal, tc.wantPrincipal)
+			}
+			for action, want := range tc.wantAccess {
+				got := Access("")
+				for _, p := range result.Permissions {
+					if p.Action == action {
+						got = p.Access
+					}
+				}
+				if got != want {
+					t.Errorf("access for %s = %q, want %q", action, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceKindForARN(t *testing.T) {
+	tests := []struct {
+		arn  string
+		want ResourceKind
+	}{
+		{"arn:aws:iam::123456789012:user/alice", ResourceUser},
+		{"arn:aws:iam::123456789012:role/deploy", ResourceServiceAccount},
+		{"arn:aws:sts::123456789012:assumed-role/deploy/session", ResourceServiceAccount},
+		{"arn:aws:iam::123456789012:root", ResourceUnknown},
+	}
+	for _, tc := range tests {
+		if got := resourceKindForARN(tc.arn); got != tc.want {
+			t.Errorf("resourceKindForARN(%q) = %q, want %q", tc.arn, got, tc.want)
+		}
+	}
+}
+
+func TestSignSigV4_SetsAuthorizationHeader(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://sts.amazonaws.com/", strings.NewReader("Action=GetCallerIdentity"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	creds := awsCredentials{accessKeyID: "AKIAEXAMPLE", secretAccessKey: "secret", region: "us-east-1"}
+	if err := signSigV4(req, creds, "sts", "Action=GetCallerIdentity"); err != nil {
+		t.Fatalf("signSigV4 returned an error: %v", err)
+	}
+
+	auth := req.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIAEXAMPLE/") {
+		t.Errorf("unexpected Authorization header: %s", auth)
+	}
+	if !strings.Contains(auth, "/us-east-1/sts/aws4_request") {
+		t.Errorf("Authorization header missing credential scope: %s", auth)
+	}
+	if req.Header.Get("X-Amz-Date") == "" {
+		t.Error("expected X-Amz-Date header to be set")
+	}
+}
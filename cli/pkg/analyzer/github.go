@@ -0,0 +1,134 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+func init() {
+	Register(&githubAnalyzer{
+		baseURL: "https://api.github.com",
+		client:  http.DefaultClient,
+	})
+}
+
+// githubAnalyzer introspects classic and fine-grained GitHub personal access
+// tokens. Classic PATs report their scopes via the X-OAuth-Scopes response
+// header on any authenticated request; fine-grained PATs don't carry scopes
+// at all, so we infer access by probing a couple of representative endpoints.
+type githubAnalyzer struct {
+	baseURL string
+	client  *http.Client
+}
+
+func (a *githubAnalyzer) Type() string { return "github_pat" }
+
+func (a *githubAnalyzer) Analyze(ctx context.Context, secret string) (*Result, error) {
+	if IsOffline(ctx) {
+		return &Result{
+			Type:         "github_pat",
+			ResourceKind: ResourceUnknown,
+			Notes:        []string{"offline mode: GitHub PATs carry no static claims, nothing to decode without a network call"},
+		}, nil
+	}
+
+	user, scopesHeader, err := a.whoami(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Type:         "github_pat",
+		ResourceKind: ResourceUser,
+		Principal:    user,
+	}
+
+	if scopesHeader != "" {
+		result.Notes = append(result.Notes, "classic PAT (scopes: "+scopesHeader+")")
+		for _, scope := range strings.Split(scopesHeader, ",") {
+			scope = strings.TrimSpace(scope)
+			if scope == "" {
+				continue
+			}
+			result.Permissions = append(result.Permissions, Permission{
+				Resource: "repo/org",
+				Action:   scope,
+				Access:   AccessYes,
+			})
+		}
+		return result, nil
+	}
+
+	result.Notes = append(result.Notes, "fine-grained PAT (no scopes header); inferring access by probing endpoints")
+	result.Permissions = append(result.Permissions, a.probeFineGrained(ctx, secret)...)
+	return result, nil
+}
+
+func (a *githubAnalyzer) whoami(ctx context.Context, token string) (user string, scopesHeader string, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/user", nil)
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("GitHub /user request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return "", "", fmt.Errorf("GitHub rejected the token (401 Unauthorized)")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("GitHub /user returned %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Login string `json:"login"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", "", fmt.Errorf("decoding GitHub /user response: %w", err)
+	}
+
+	return body.Login, resp.Header.Get("X-OAuth-Scopes"), nil
+}
+
+// probeFineGrained infers permissions for a fine-grained PAT by attempting a
+// handful of representative, low-risk requests. Fine-grained PATs don't
+// expose their permission set via headers, so this is a best-effort probe,
+// not an exhaustive report.
+func (a *githubAnalyzer) probeFineGrained(ctx context.Context, token string) []Permission {
+	probes := []struct {
+		resource, action, method, path string
+	}{
+		{"repos", "read", http.MethodGet, "/user/repos?per_page=1"},
+		{"orgs", "read", http.MethodGet, "/user/orgs?per_page=1"},
+		{"user:email", "read", http.MethodGet, "/user/emails"},
+	}
+
+	perms := make([]Permission, 0, len(probes))
+	for _, p := range probes {
+		access := AccessUnknown
+		req, err := http.NewRequestWithContext(ctx, p.method, a.baseURL+p.path, nil)
+		if err == nil {
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("Accept", "application/vnd.github+json")
+			if resp, err := a.client.Do(req); err == nil {
+				resp.Body.Close()
+				switch {
+				case resp.StatusCode == http.StatusOK:
+					access = AccessYes
+				case resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized:
+					access = AccessNo
+				}
+			}
+		}
+		perms = append(perms, Permission{Resource: p.resource, Action: p.action, Access: access})
+	}
+	return perms
+}
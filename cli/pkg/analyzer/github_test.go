@@ -0,0 +1,103 @@
+package analyzer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitHubAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        http.HandlerFunc
+		wantErr        bool
+		wantPrincipal  string
+		wantPermission int // expected len(result.Permissions)
+		wantNote       string
+	}{
+		{
+			name: "classic PAT reports scopes header",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-OAuth-Scopes", "repo, read:org")
+				w.Write([]byte(`{"login":"octocat"}`))
+			},
+			wantPrincipal:  "octocat",
+			wantPermission: 2,
+		},
+		{
+			name: "fine-grained PAT has no scopes header",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/user":
+					w.Write([]byte(`{"login":"fg-bot"}`))
+				case "/user/repos":
+					w.WriteHeader(http.StatusOK)
+				case "/user/orgs":
+					w.WriteHeader(http.StatusForbidden)
+				case "/user/emails":
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+			},
+			wantPrincipal:  "fg-bot",
+			wantPermission: 3,
+			wantNote:       "fine-grained PAT",
+		},
+		{
+			name: "unauthorized token",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+			wantErr: true,
+		},
+		{
+			name: "unexpected status",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := httptest.NewServer(tc.handler)
+			defer ts.Close()
+
+			a := &githubAnalyzer{baseURL: ts.URL, client: ts.Client()}
+			result, err := a.Analyze(context.Background(), "token")
+
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got result %+v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Principal != tc.wantPrincipal {
+				t.Errorf("Principal = %q, want %q", result.Principal, tc.wantPrincipal)
+			}
+			if len(result.Permissions) != tc.wantPermission {
+				t.Errorf("len(Permissions) = %d, want %d", len(result.Permissions), tc.wantPermission)
+			}
+			if tc.wantNote != "" && !containsNote(result.Notes, tc.wantNote) {
+				t.Errorf("expected a note containing %q, got %v", tc.wantNote, result.Notes)
+			}
+		})
+	}
+}
+
+func TestGitHubAnalyzer_Offline(t *testing.T) {
+	a := &githubAnalyzer{baseURL: "http://unused.invalid", client: http.DefaultClient}
+	ctx := WithOffline(context.Background(), true)
+
+	result, err := a.Analyze(ctx, "token")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !containsNote(result.Notes, "offline mode") {
+		t.Errorf("expected an offline-mode note, got %v", result.Notes)
+	}
+}
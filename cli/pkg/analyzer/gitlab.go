@@ -0,0 +1,92 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func init() {
+	Register(&gitlabAnalyzer{
+		baseURL: "https://gitlab.com/api/v4",
+		client:  http.DefaultClient,
+	})
+}
+
+// gitlabAnalyzer introspects GitLab personal access tokens via the
+// self-describing /personal_access_tokens/self endpoint, which returns
+// scopes and expiry directly rather than requiring probes.
+type gitlabAnalyzer struct {
+	baseURL string
+	client  *http.Client
+}
+
+func (a *gitlabAnalyzer) Type() string { return "gitlab_pat" }
+
+func (a *gitlabAnalyzer) Analyze(ctx context.Context, secret string) (*Result, error) {
+	if IsOffline(ctx) {
+		return &Result{
+			Type:         "gitlab_pat",
+			ResourceKind: ResourceUnknown,
+			Notes:        []string{"offline mode: GitLab PATs carry no static claims, nothing to decode without a network call"},
+		}, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/personal_access_tokens/self", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", secret)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GitLab /personal_access_tokens/self request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("GitLab rejected the token (401 Unauthorized)")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitLab /personal_access_tokens/self returned %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Name      string   `json:"name"`
+		UserID    int      `json:"user_id"`
+		Scopes    []string `json:"scopes"`
+		ExpiresAt *string  `json:"expires_at"`
+		Active    bool     `json:"active"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decoding GitLab response: %w", err)
+	}
+
+	result := &Result{
+		Type:         "gitlab_pat",
+		ResourceKind: ResourceUser,
+		Principal:    fmt.Sprintf("user_id=%d (%s)", body.UserID, body.Name),
+	}
+
+	for _, scope := range body.Scopes {
+		result.Permissions = append(result.Permissions, Permission{
+			Resource: "gitlab",
+			Action:   scope,
+			Access:   AccessYes,
+		})
+	}
+
+	if body.ExpiresAt != nil {
+		if exp, err := time.Parse("2006-01-02", *body.ExpiresAt); err == nil {
+			result.Expiry = &exp
+		}
+	}
+
+	if !body.Active {
+		result.Notes = append(result.Notes, "token reports active=false")
+	}
+
+	return result, nil
+}
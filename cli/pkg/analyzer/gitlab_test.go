@@ -0,0 +1,93 @@
+package analyzer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitLabAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name          string
+		handler       http.HandlerFunc
+		wantErr       bool
+		wantPrincipal string
+		wantScopes    int
+		wantNote      string
+	}{
+		{
+			name: "active token with scopes and expiry",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"name":"ci-bot","user_id":42,"scopes":["api","read_repository"],"expires_at":"2099-01-01","active":true}`))
+			},
+			wantPrincipal: "user_id=42 (ci-bot)",
+			wantScopes:    2,
+		},
+		{
+			name: "inactive token",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"name":"stale","user_id":7,"scopes":["read_api"],"active":false}`))
+			},
+			wantPrincipal: "user_id=7 (stale)",
+			wantScopes:    1,
+			wantNote:      "active=false",
+		},
+		{
+			name: "unauthorized token",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+			wantErr: true,
+		},
+		{
+			name: "unexpected status",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := httptest.NewServer(tc.handler)
+			defer ts.Close()
+
+			a := &gitlabAnalyzer{baseURL: ts.URL, client: ts.Client()}
+			result, err := a.Analyze(context.Background(), "token")
+
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got result %+v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Principal != tc.wantPrincipal {
+				t.Errorf("Principal = %q, want %q", result.Principal, tc.wantPrincipal)
+			}
+			if len(result.Permissions) != tc.wantScopes {
+				t.Errorf("len(Permissions) = %d, want %d", len(result.Permissions), tc.wantScopes)
+			}
+			if tc.wantNote != "" && !containsNote(result.Notes, tc.wantNote) {
+				t.Errorf("expected a note containing %q, got %v", tc.wantNote, result.Notes)
+			}
+		})
+	}
+}
+
+func TestGitLabAnalyzer_Offline(t *testing.T) {
+	a := &gitlabAnalyzer{baseURL: "http://unused.invalid", client: http.DefaultClient}
+	ctx := WithOffline(context.Background(), true)
+
+	result, err := a.Analyze(ctx, "token")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !containsNote(result.Notes, "offline mode") {
+		t.Errorf("expected an offline-mode note, got %v", result.Notes)
+	}
+}
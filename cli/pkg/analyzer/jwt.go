@@ -0,0 +1,112 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register(&jwtAnalyzer{})
+}
+
+// jwtAnalyzer decodes a bearer JWT's header and payload without verifying
+// the signature (we don't generally have the issuer's key material) and
+// reports the claims that matter for a human deciding how dangerous a leak
+// is: audience, expiry, and issuer.
+type jwtAnalyzer struct{}
+
+func (a *jwtAnalyzer) Type() string { return "jwt" }
+
+func (a *jwtAnalyzer) Analyze(ctx context.Context, secret string) (*Result, error) {
+	parts := strings.Split(strings.TrimSpace(secret), ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("not a well-formed JWT (expected at least header.payload)")
+	}
+
+	header, err := decodeSegment(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("decoding JWT header: %w", err)
+	}
+
+	payload, err := decodeSegment(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("decoding JWT payload: %w", err)
+	}
+
+	var hdr struct {
+		Alg string `json:"alg"`
+		Typ string `json:"typ"`
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(header, &hdr); err != nil {
+		return nil, fmt.Errorf("parsing JWT header: %w", err)
+	}
+
+	var claims struct {
+		Subject  string `json:"sub"`
+		Issuer   string `json:"iss"`
+		Audience any    `json:"aud"`
+		Expiry   int64  `json:"exp"`
+		IssuedAt int64  `json:"iat"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("parsing JWT payload: %w", err)
+	}
+
+	result := &Result{
+		Type:         "jwt",
+		ResourceKind: ResourceToken,
+		Principal:    claims.Subject,
+		Notes: []string{
+			fmt.Sprintf("alg=%s", hdr.Alg),
+		},
+	}
+
+	if claims.Issuer != "" {
+		result.Notes = append(result.Notes, "iss="+claims.Issuer)
+	}
+	if aud := formatAudience(claims.Audience); aud != "" {
+		result.Notes = append(result.Notes, "aud="+aud)
+	}
+
+	if claims.Expiry > 0 {
+		exp := time.Unix(claims.Expiry, 0).UTC()
+		result.Expiry = &exp
+		if exp.Before(time.Now()) {
+			result.Notes = append(result.Notes, "token is expired")
+		}
+	} else {
+		result.Notes = append(result.Notes, "token has no exp claim (does not expire)")
+	}
+
+	if !IsOffline(ctx) {
+		result.Notes = append(result.Notes, "no network introspection available for generic JWTs; showing decoded claims only")
+	}
+
+	return result, nil
+}
+
+func decodeSegment(seg string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(seg)
+}
+
+func formatAudience(aud any) string {
+	switch v := aud.(type) {
+	case string:
+		return v
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ",")
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,132 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// makeJWT builds a JWT ("header.payload.signature") from raw JSON segments
+// with a placeholder signature, since jwtAnalyzer never verifies it.
+func makeJWT(t *testing.T, header, payload string) string {
+	t.Helper()
+	enc := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+	return enc(header) + "." + enc(payload) + ".signature"
+}
+
+func TestJWTAnalyzer_Analyze(t *testing.T) {
+	a := &jwtAnalyzer{}
+	future := time.Now().Add(time.Hour).Unix()
+	past := time.Now().Add(-time.Hour).Unix()
+
+	tests := []struct {
+		name        string
+		header      string
+		payload     string
+		wantErr     bool
+		wantExpired bool
+		noExp       bool
+	}{
+		{
+			name:    "valid token with audience string and future expiry",
+			header:  `{"alg":"HS256","typ":"JWT"}`,
+			payload: `{"sub":"user-1","iss":"https://issuer.example","aud":"api","exp":` + strconv.FormatInt(future, 10) + `}`,
+		},
+		{
+			name:    "audience array",
+			header:  `{"alg":"RS256"}`,
+			payload: `{"sub":"user-2","aud":["a","b"],"exp":` + strconv.FormatInt(future, 10) + `}`,
+		},
+		{
+			name:        "expired token",
+			header:      `{"alg":"HS256"}`,
+			payload:     `{"sub":"user-3","exp":` + strconv.FormatInt(past, 10) + `}`,
+			wantExpired: true,
+		},
+		{
+			name:    "no exp claim",
+			header:  `{"alg":"HS256"}`,
+			payload: `{"sub":"user-4"}`,
+			noExp:   true,
+		},
+		{
+			name:    "malformed header JSON",
+			header:  `not-json`,
+			payload: `{"sub":"user-5"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			token := makeJWT(t, tc.header, tc.payload)
+			result, err := a.Analyze(context.Background(), token)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got result %+v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tc.wantExpired && !containsNote(result.Notes, "token is expired") {
+				t.Errorf("expected an expired-token note, got %v", result.Notes)
+			}
+			if tc.noExp {
+				if result.Expiry != nil {
+					t.Errorf("expected nil Expiry, got %v", result.Expiry)
+				}
+				if !containsNote(result.Notes, "token has no exp claim (does not expire)") {
+					t.Errorf("expected a no-exp note, got %v", result.Notes)
+				}
+			}
+		})
+	}
+}
+
+func TestJWTAnalyzer_NotWellFormed(t *testing.T) {
+	a := &jwtAnalyzer{}
+	if _, err := a.Analyze(context.Background(), "not-a-jwt"); err == nil {
+		t.Fatal("expected an error for a token with no '.' segments")
+	}
+}
+
+func TestFormatAudience(t *testing.T) {
+	tests := []struct {
+		name string
+		aud  any
+		want string
+	}{
+		{"string", "api", "api"},
+		{"string slice boxed as any", []any{"a", "b"}, "a,b"},
+		{"mixed slice drops non-strings", []any{"a", 1.0}, "a"},
+		{"unsupported type", 42, ""},
+		{"nil", nil, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := formatAudience(tc.aud); got != tc.want {
+				t.Errorf("formatAudience(%v) = %q, want %q", tc.aud, got, tc.want)
+			}
+		})
+	}
+}
+
+func containsNote(notes []string, want string) bool {
+	for _, n := range notes {
+		if strings.Contains(n, want) {
+			return true
+		}
+	}
+	return false
+}
+
@@ -2,14 +2,23 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"strconv"
 	"time"
+
+	"github.com/roset-dev/roset/monorepo/cli/pkg/log"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Client is a minimal Roset API client for CLI operations.
@@ -17,6 +26,22 @@ type Client struct {
 	BaseURL    string
 	APIKey     string
 	HTTPClient *http.Client
+	// Retry configures doRequest's backoff and retry behavior. It's set to
+	// defaultRetryPolicy by NewClient; override fields on it for callers
+	// that need different behavior.
+	Retry RetryPolicy
+
+	// breaker trips after repeated failures so a dead control plane gets
+	// one API call, not every retry of every command, until it cools down.
+	breaker *circuitBreaker
+
+	// RefreshFunc, if set, is called by doRequest the first time a request
+	// comes back 401. It should exchange whatever refresh credential the
+	// caller holds for a new access token and return it; doRequest installs
+	// it as c.APIKey and retries the same attempt once. Left nil, a 401 is
+	// returned to the caller untouched, same as before this existed. See
+	// config.RefreshFunc for the profile-backed OAuth device-flow case.
+	RefreshFunc func(ctx context.Context) (string, error)
 }
 
 // NewClient creates a new API client with the given base URL and API key.
@@ -30,9 +55,18 @@ func NewClient(baseURL, apiKey string) *Client {
 		HTTPClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		Retry:   defaultRetryPolicy(),
+		breaker: newCircuitBreaker(5, 30*time.Second),
 	}
 }
 
+// WithRefresh installs fn as the client's RefreshFunc and returns c, so
+// callers can chain it onto NewClient.
+func (c *Client) WithRefresh(fn func(ctx context.Context) (string, error)) *Client {
+	c.RefreshFunc = fn
+	return c
+}
+
 // WhoamiResponse contains the authenticated user's identity info.
 type WhoamiResponse struct {
 	TenantID    string `json:"tenantId"`
@@ -63,6 +97,15 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("%s (%s)", e.Message, e.Code)
 }
 
+// logAPIError logs an API-level failure (as opposed to a transport failure
+// already logged by doRequest) with its request ID, so a failing command run
+// with --verbose has a log line carrying the same ID that was sent in the
+// X-Request-Id header and echoed back in the error message.
+func logAPIError(err *APIError) *APIError {
+	log.Error("api error", "request_id", err.RequestID, "code", err.Code, "status", err.Status, "message", err.Message)
+	return err
+}
+
 // generateRequestID creates a unique request ID for tracing.
 func generateRequestID() string {
 	b := make([]byte, 8)
@@ -70,18 +113,61 @@ func generateRequestID() string {
 	return "cli-" + hex.EncodeToString(b)
 }
 
-// doRequest performs an authenticated HTTP request with retry logic.
-func (c *Client) doRequest(method, path string) ([]byte, int, time.Duration, string, error) {
+// doRequest performs an authenticated HTTP request, retrying per c.Retry
+// until it succeeds, exhausts MaxElapsed, or hits a non-retryable failure.
+// ctx carries the per-invocation root span (see cmd/root.go); each attempt
+// gets its own child span with the W3C traceparent/tracestate injected into
+// the outgoing request alongside X-Request-Id. reqBody is sent as-is (as
+// JSON; callers set it via doJSON) and nil for bodyless requests. Non-GET
+// requests get an Idempotency-Key generated once for the logical call and
+// reused across retries, so the server can de-dupe a retried mutation.
+func (c *Client) doRequest(ctx context.Context, method, path string, reqBody []byte) ([]byte, int, time.Duration, string, error) {
 	url := c.BaseURL + path
 	requestID := generateRequestID()
+	reqLog := log.With("request_id", requestID, "method", method, "path", path)
+
+	if !c.breaker.allow() {
+		reqLog.Warn("circuit breaker open, refusing request")
+		return nil, 0, 0, requestID, logAPIError(&APIError{
+			Message:   "API circuit breaker is open after repeated failures; not retrying",
+			Code:      "CIRCUIT_OPEN",
+			RequestID: requestID,
+		})
+	}
+
+	var idempotencyKey string
+	if method != http.MethodGet {
+		idempotencyKey = generateUUIDv4()
+	}
 
 	var lastErr error
 	var totalLatency time.Duration
+	var delay time.Duration
+	var refreshed bool
+	start := time.Now()
 
-	// Retry up to 3 times for 429/5xx errors
-	for attempt := 0; attempt < 3; attempt++ {
-		req, err := http.NewRequest(method, url, nil)
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && time.Since(start) >= c.Retry.MaxElapsed {
+			break
+		}
+
+		attemptCtx, span := telemetry.Tracer().Start(ctx, method+" "+path, trace.WithAttributes(
+			attribute.String("http.method", method),
+			attribute.String("http.url", url),
+			attribute.Int("roset.attempt", attempt+1),
+			attribute.String("roset.request_id", requestID),
+		))
+
+		var bodyReader io.Reader
+		if len(reqBody) > 0 {
+			bodyReader = bytes.NewReader(reqBody)
+		}
+
+		req, err := http.NewRequestWithContext(attemptCtx, method, url, bodyReader)
 		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			span.End()
 			return nil, 0, 0, requestID, fmt.Errorf("failed to create request: %w", err)
 		}
 
@@ -89,84 +175,178 @@ func (c *Client) doRequest(method, path string) ([]byte, int, time.Duration, str
 		req.Header.Set("Accept", "application/json")
 		req.Header.Set("User-Agent", "roset-cli/1.0")
 		req.Header.Set("X-Request-Id", requestID)
+		if len(reqBody) > 0 {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if idempotencyKey != "" {
+			req.Header.Set("Idempotency-Key", idempotencyKey)
+		}
+		otel.GetTextMapPropagator().Inject(attemptCtx, propagation.HeaderCarrier(req.Header))
 
-		start := time.Now()
+		reqStart := time.Now()
 		resp, err := c.HTTPClient.Do(req)
-		latency := time.Since(start)
+		latency := time.Since(reqStart)
 		totalLatency += latency
 
 		if err != nil {
 			lastErr = fmt.Errorf("request failed: %w", err)
+			reqLog.Warn("http attempt failed", "attempt", attempt+1, "latency_ms", latency.Milliseconds(), "error", err)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			span.End()
+			c.breaker.recordFailure()
+			if !isRetryableError(err) {
+				break
+			}
+			delay = c.Retry.nextDelay(delay)
+			if !sleepCtx(ctx, delay) {
+				break
+			}
 			continue
 		}
-		defer resp.Body.Close()
 
 		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
 		if err != nil {
+			reqLog.Error("failed to read response body", "attempt", attempt+1, "status", resp.StatusCode)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			span.End()
+			c.breaker.recordFailure()
 			return nil, resp.StatusCode, totalLatency, requestID, fmt.Errorf("failed to read response: %w", err)
 		}
 
-		// Check for retryable status codes
-		if resp.StatusCode == 429 || resp.StatusCode >= 500 {
-			// Respect Retry-After header if present
-			if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
-				if secs, err := strconv.Atoi(retryAfter); err == nil && secs > 0 && secs <= 30 {
-					time.Sleep(time.Duration(secs) * time.Second)
-					continue
-				}
+		span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+		if resp.StatusCode >= 400 {
+			span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", resp.StatusCode))
+		} else {
+			span.SetStatus(codes.Ok, "")
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && c.RefreshFunc != nil && !refreshed {
+			refreshed = true
+			reqLog.Info("access token rejected, attempting refresh", "attempt", attempt+1)
+			span.End()
+			newKey, rerr := c.RefreshFunc(ctx)
+			if rerr != nil {
+				reqLog.Warn("token refresh failed, returning original 401", "error", rerr)
+			} else {
+				c.APIKey = newKey
+				continue
 			}
-			// Default backoff: 1s, 2s
-			time.Sleep(time.Duration(attempt+1) * time.Second)
+		}
+
+		if c.Retry.RetryableStatus[resp.StatusCode] {
+			retryAfter := resp.Header.Get("Retry-After")
+			reqLog.Info("http attempt retrying", "attempt", attempt+1, "status", resp.StatusCode, "latency_ms", latency.Milliseconds(), "retry_after", retryAfter)
+			span.SetAttributes(attribute.String("roset.retry_after", retryAfter))
+			span.End()
+			c.breaker.recordFailure()
 			lastErr = fmt.Errorf("server error: %d", resp.StatusCode)
+
+			if d, ok := retryAfterDelay(retryAfter); ok {
+				delay = d
+				if delay > c.Retry.MaxDelay {
+					delay = c.Retry.MaxDelay
+				}
+			} else {
+				delay = c.Retry.nextDelay(delay)
+			}
+			if !sleepCtx(ctx, delay) {
+				break
+			}
 			continue
 		}
 
+		span.End()
+		reqLog.Debug("http attempt succeeded", "attempt", attempt+1, "status", resp.StatusCode, "latency_ms", latency.Milliseconds())
+		c.breaker.recordSuccess()
 		return body, resp.StatusCode, totalLatency, requestID, nil
 	}
 
+	reqLog.Error("http request exhausted retries", "latency_ms", totalLatency.Milliseconds(), "error", lastErr)
 	return nil, 0, totalLatency, requestID, lastErr
 }
 
-// Whoami returns the authenticated user's identity.
-// It also returns the request latency for diagnostics.
-func (c *Client) Whoami() (*WhoamiResponse, time.Duration, error) {
-	// Try /v1/org/members to validate auth - any member can list members
-	body, status, latency, requestID, err := c.doRequest("GET", "/v1/org/members")
-	if err != nil {
-		return nil, latency, err
-	}
-
+// apiErrorFromResponse turns a non-2xx response into an *APIError: 401/403
+// get a human-friendly message, anything else tries the API's own
+// {message,code} envelope first and falls back to a generic one keyed off
+// the status code. It also logs the failure, same as logAPIError.
+func apiErrorFromResponse(status int, body []byte, requestID string) *APIError {
 	if status == 401 {
-		return nil, latency, &APIError{
+		return logAPIError(&APIError{
 			Message:   "Invalid or expired API key",
 			Code:      "UNAUTHORIZED",
 			Status:    401,
 			RequestID: requestID,
-		}
+		})
 	}
 
 	if status == 403 {
-		return nil, latency, &APIError{
+		return logAPIError(&APIError{
 			Message:   "API key does not have permission to access this resource",
 			Code:      "FORBIDDEN",
 			Status:    403,
 			RequestID: requestID,
+		})
+	}
+
+	var apiErr APIError
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+		apiErr.Status = status
+		apiErr.RequestID = requestID
+		return logAPIError(&apiErr)
+	}
+	return logAPIError(&APIError{
+		Message:   fmt.Sprintf("API returned status %d", status),
+		Code:      "API_ERROR",
+		Status:    status,
+		RequestID: requestID,
+	})
+}
+
+// doJSON generalizes doRequest for the typed endpoints (keys.go): it
+// JSON-encodes reqBody (nil for a bodyless GET/POST), runs it through
+// doRequest's existing retry/tracing/circuit-breaker machinery, maps a
+// non-2xx response to an *APIError, and decodes a successful body into out
+// (left nil by callers like RevokeKey that don't need the response).
+func (c *Client) doJSON(ctx context.Context, method, path string, reqBody, out any) error {
+	var bodyBytes []byte
+	if reqBody != nil {
+		b, err := json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("encoding request body: %w", err)
 		}
+		bodyBytes = b
 	}
 
+	body, status, _, requestID, err := c.doRequest(ctx, method, path, bodyBytes)
+	if err != nil {
+		return err
+	}
 	if status >= 400 {
-		var apiErr APIError
-		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
-			apiErr.Status = status
-			apiErr.RequestID = requestID
-			return nil, latency, &apiErr
-		}
-		return nil, latency, &APIError{
-			Message:   fmt.Sprintf("API returned status %d", status),
-			Code:      "API_ERROR",
-			Status:    status,
-			RequestID: requestID,
-		}
+		return apiErrorFromResponse(status, body, requestID)
+	}
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// Whoami returns the authenticated user's identity.
+// It also returns the request latency for diagnostics.
+func (c *Client) Whoami(ctx context.Context) (*WhoamiResponse, time.Duration, error) {
+	// Try /v1/org/members to validate auth - any member can list members
+	body, status, latency, requestID, err := c.doRequest(ctx, "GET", "/v1/org/members", nil)
+	if err != nil {
+		return nil, latency, err
+	}
+
+	if status >= 400 {
+		return nil, latency, apiErrorFromResponse(status, body, requestID)
 	}
 
 	// Parse member list response to extract info
@@ -192,9 +372,9 @@ func (c *Client) Whoami() (*WhoamiResponse, time.Duration, error) {
 }
 
 // Ping checks API connectivity without authentication.
-func (c *Client) Ping() (*HealthResponse, time.Duration, error) {
+func (c *Client) Ping(ctx context.Context) (*HealthResponse, time.Duration, error) {
 	// Try a simple request - even 401 means API is reachable
-	_, status, latency, _, err := c.doRequest("GET", "/v1/org/members")
+	_, status, latency, _, err := c.doRequest(ctx, "GET", "/v1/org/members", nil)
 	if err != nil {
 		// Network error
 		return nil, latency, err
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetryPolicy is defaultRetryPolicy scaled down so tests exercising
+// several retries don't have to wait out real backoff.
+func fastRetryPolicy() RetryPolicy {
+	p := defaultRetryPolicy()
+	p.BaseDelay = time.Millisecond
+	p.MaxDelay = 20 * time.Millisecond
+	p.MaxElapsed = time.Second
+	return p
+}
+
+func TestDoRequest_RetriesOn503ThenSucceeds(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if n < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "test-key")
+	c.Retry = fastRetryPolicy()
+
+	body, status, _, _, err := c.doRequest(context.Background(), http.MethodGet, "/v1/thing", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want 200", status)
+	}
+	if string(body) != `{"ok":true}` {
+		t.Errorf("body = %s", body)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3 (2 failures then a success)", calls)
+	}
+}
+
+func TestDoRequest_RetriesOn429WithRetryAfter(t *testing.T) {
+	var calls int32
+	var gotRetryAfterSleep time.Duration
+	start := time.Now()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		gotRetryAfterSleep = time.Since(start)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "test-key")
+	c.Retry = fastRetryPolicy()
+
+	_, status, _, _, err := c.doRequest(context.Background(), http.MethodGet, "/v1/thing", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want 200", status)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+	// Retry-After: 0 means doRequest shouldn't impose its own backoff on
+	// top of it.
+	if gotRetryAfterSleep > 100*time.Millisecond {
+		t.Errorf("retry after Retry-After:0 took %v, want near-immediate", gotRetryAfterSleep)
+	}
+}
+
+func TestDoRequest_GivesUpAfterMaxElapsed(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "test-key")
+	c.Retry = RetryPolicy{
+		BaseDelay:       time.Millisecond,
+		MaxDelay:        5 * time.Millisecond,
+		MaxElapsed:      30 * time.Millisecond,
+		JitterFactor:    3.0,
+		RetryableStatus: defaultRetryPolicy().RetryableStatus,
+	}
+
+	_, _, _, _, err := c.doRequest(context.Background(), http.MethodGet, "/v1/thing", nil)
+	if err == nil {
+		t.Fatal("expected an error once MaxElapsed is exceeded")
+	}
+	if atomic.LoadInt32(&calls) < 2 {
+		t.Errorf("calls = %d, want at least 2 attempts before giving up", calls)
+	}
+}
+
+func TestDoRequest_DoesNotRetryNonRetryableStatus(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "test-key")
+	c.Retry = fastRetryPolicy()
+
+	_, status, _, _, err := c.doRequest(context.Background(), http.MethodGet, "/v1/thing", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", status)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want exactly 1 (404 is not retryable)", calls)
+	}
+}
+
+func TestDoRequest_CircuitBreakerOpensAfterRepeatedFailures(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "test-key")
+	c.Retry = fastRetryPolicy()
+	c.Retry.MaxElapsed = 5 * time.Millisecond // give up fast so each call is ~1 attempt
+	c.breaker = newCircuitBreaker(2, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if _, _, _, _, err := c.doRequest(context.Background(), http.MethodGet, "/v1/thing", nil); err == nil {
+			t.Fatalf("call %d: expected an error", i)
+		}
+	}
+
+	callsBeforeTrip := atomic.LoadInt32(&calls)
+	_, _, _, _, err := c.doRequest(context.Background(), http.MethodGet, "/v1/thing", nil)
+	if err == nil {
+		t.Fatal("expected CIRCUIT_OPEN error")
+	}
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.Code != "CIRCUIT_OPEN" {
+		t.Fatalf("expected a CIRCUIT_OPEN APIError, got %v", err)
+	}
+	if atomic.LoadInt32(&calls) != callsBeforeTrip {
+		t.Error("expected the circuit-open call to make no HTTP request at all")
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitBreakerState is the state of a circuitBreaker.
+type circuitBreakerState int
+
+const (
+	circuitClosed circuitBreakerState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker trips after threshold consecutive failures (transport
+// errors or retryable status codes) and refuses further requests until
+// cooldown has elapsed, at which point it lets exactly one request through
+// (half-open) to probe whether the API has recovered. One breaker guards
+// one Client, which is itself bound to one host, so there's no need to key
+// it by host internally.
+type circuitBreaker struct {
+	mu                  sync.Mutex
+	state               circuitBreakerState
+	consecutiveFailures int
+	threshold           int
+	cooldown            time.Duration
+	openedAt            time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// allow reports whether a request may proceed, transitioning a tripped
+// breaker to half-open once its cooldown has elapsed.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitOpen {
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = circuitHalfOpen
+	}
+	return true
+}
+
+// recordSuccess closes the breaker and resets its failure count.
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.consecutiveFailures = 0
+	b.state = circuitClosed
+}
+
+// recordFailure counts a failure, tripping the breaker once threshold
+// consecutive failures are seen. A failure while half-open re-opens it
+// immediately, since the probe request showed the API is still down.
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitHalfOpen {
+		b.state = circuitOpen
+		b.openedAt = time.Now()
+		return
+	}
+
+	b.consecutiveFailures++
+	if b.consecutiveFailures >= b.threshold {
+		b.state = circuitOpen
+		b.openedAt = time.Now()
+	}
+}
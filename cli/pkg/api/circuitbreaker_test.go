@@ -0,0 +1,90 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_OpensAfterThresholdFailures(t *testing.T) {
+	b := newCircuitBreaker(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if !b.allow() {
+			t.Fatalf("attempt %d: expected breaker to still allow requests", i)
+		}
+		b.recordFailure()
+	}
+	if !b.allow() {
+		t.Fatal("expected breaker to still allow requests before threshold is reached")
+	}
+	b.recordFailure() // 3rd consecutive failure trips it
+
+	if b.allow() {
+		t.Fatal("expected breaker to refuse requests once threshold failures are reached")
+	}
+}
+
+func TestCircuitBreaker_SuccessResetsFailureCount(t *testing.T) {
+	b := newCircuitBreaker(3, time.Minute)
+
+	b.recordFailure()
+	b.recordFailure()
+	b.recordSuccess()
+	b.recordFailure()
+	b.recordFailure()
+
+	if !b.allow() {
+		t.Fatal("expected breaker to still be closed: the success should have reset the failure streak")
+	}
+}
+
+func TestCircuitBreaker_HalfOpenAfterCooldown(t *testing.T) {
+	b := newCircuitBreaker(1, 10*time.Millisecond)
+
+	b.recordFailure() // trips it immediately (threshold=1)
+	if b.allow() {
+		t.Fatal("expected breaker to refuse requests immediately after tripping")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if !b.allow() {
+		t.Fatal("expected breaker to allow one probe request after cooldown elapses")
+	}
+}
+
+func TestCircuitBreaker_FailureWhileHalfOpenReopensImmediately(t *testing.T) {
+	b := newCircuitBreaker(1, 10*time.Millisecond)
+
+	b.recordFailure()
+	time.Sleep(20 * time.Millisecond)
+	if !b.allow() { // transitions to half-open
+		t.Fatal("expected breaker to allow the half-open probe")
+	}
+
+	b.recordFailure() // probe failed
+	if b.allow() {
+		t.Fatal("expected breaker to re-open immediately after a failed half-open probe")
+	}
+
+	// It shouldn't reopen for another full cooldown window measured from
+	// the probe failure, not the original trip.
+	time.Sleep(5 * time.Millisecond)
+	if b.allow() {
+		t.Fatal("expected breaker to still be within its new cooldown window")
+	}
+}
+
+func TestCircuitBreaker_SuccessAfterHalfOpenCloses(t *testing.T) {
+	b := newCircuitBreaker(1, 10*time.Millisecond)
+
+	b.recordFailure()
+	time.Sleep(20 * time.Millisecond)
+	if !b.allow() {
+		t.Fatal("expected breaker to allow the half-open probe")
+	}
+
+	b.recordSuccess()
+	if !b.allow() {
+		t.Fatal("expected breaker to be closed and allowing requests after a successful probe")
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// APIKey is one Roset API key as returned by the keys endpoints. Secret is
+// only ever populated by CreateKey and RotateKey, and only once - the API
+// never returns a key's secret again after that response.
+type APIKey struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Role      string     `json:"role,omitempty"`
+	Scopes    []string   `json:"scopes,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	Revoked   bool       `json:"revoked"`
+	Secret    string     `json:"secret,omitempty"`
+}
+
+// CreateKeyRequest is the body of a CreateKey call.
+type CreateKeyRequest struct {
+	Name   string   `json:"name"`
+	Role   string   `json:"role,omitempty"`
+	TTL    string   `json:"ttl,omitempty"`
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+type keyListResponse struct {
+	Items []APIKey `json:"items"`
+}
+
+// ListKeys returns every API key visible to the caller. Secrets are never
+// included in list responses.
+func (c *Client) ListKeys(ctx context.Context) ([]APIKey, error) {
+	var resp keyListResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/v1/keys", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// ShowKey returns one API key's metadata (never its secret - ShowKey is for
+// inspecting an existing key, not retrieving one).
+func (c *Client) ShowKey(ctx context.Context, id string) (*APIKey, error) {
+	var key APIKey
+	if err := c.doJSON(ctx, http.MethodGet, "/v1/keys/"+id, nil, &key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// CreateKey provisions a new API key. The response is the only time its
+// secret is ever returned.
+func (c *Client) CreateKey(ctx context.Context, req CreateKeyRequest) (*APIKey, error) {
+	var key APIKey
+	if err := c.doJSON(ctx, http.MethodPost, "/v1/keys", req, &key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RevokeKey permanently disables an API key.
+func (c *Client) RevokeKey(ctx context.Context, id string) error {
+	return c.doJSON(ctx, http.MethodPost, "/v1/keys/"+id+"/revoke", nil, nil)
+}
+
+// RotateKey revokes id's current secret and issues a new one under the same
+// key ID, name, role, and scopes. Like CreateKey, the new secret is only
+// ever returned in this response.
+func (c *Client) RotateKey(ctx context.Context, id string) (*APIKey, error) {
+	var key APIKey
+	if err := c.doJSON(ctx, http.MethodPost, "/v1/keys/"+id+"/rotate", nil, &key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures doRequest's retry behavior. NewClient populates a
+// Client's Retry field with defaultRetryPolicy; callers that need different
+// behavior (e.g. a longer MaxElapsed for a long-running batch command) can
+// overwrite fields on the returned Client.
+type RetryPolicy struct {
+	// BaseDelay is the shortest backoff, and the floor of every
+	// decorrelated-jitter draw.
+	BaseDelay time.Duration
+	// MaxDelay caps how long any single backoff sleep can run.
+	MaxDelay time.Duration
+	// MaxElapsed bounds the total wall-clock time doRequest spends
+	// retrying a single logical call, across all attempts.
+	MaxElapsed time.Duration
+	// JitterFactor is the multiplier in the decorrelated-jitter formula
+	// (sleep = random_between(BaseDelay, prev*JitterFactor)).
+	JitterFactor float64
+	// RetryableStatus is the set of HTTP status codes worth retrying.
+	RetryableStatus map[int]bool
+}
+
+func defaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		BaseDelay:    200 * time.Millisecond,
+		MaxDelay:     20 * time.Second,
+		MaxElapsed:   60 * time.Second,
+		JitterFactor: 3.0,
+		RetryableStatus: map[int]bool{
+			http.StatusTooManyRequests:     true,
+			http.StatusInternalServerError: true,
+			http.StatusBadGateway:          true,
+			http.StatusServiceUnavailable:  true,
+			http.StatusGatewayTimeout:      true,
+		},
+	}
+}
+
+// nextDelay implements decorrelated-jitter backoff (as described in AWS's
+// "Exponential Backoff And Jitter" post): each sleep is drawn uniformly from
+// [BaseDelay, prev*JitterFactor), capped at MaxDelay. This spreads retries
+// from many concurrent CLI invocations out better than a fixed exponential
+// schedule, at the cost of being less predictable attempt-to-attempt.
+func (p RetryPolicy) nextDelay(prev time.Duration) time.Duration {
+	if prev <= 0 {
+		prev = p.BaseDelay
+	}
+	upper := time.Duration(float64(prev) * p.JitterFactor)
+	if upper <= p.BaseDelay {
+		upper = p.BaseDelay + 1
+	}
+	delay := p.BaseDelay + randDuration(upper-p.BaseDelay)
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// randDuration returns a cryptographically random duration in [0, max).
+func randDuration(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
+	if err != nil {
+		return max / 2
+	}
+	return time.Duration(n.Int64())
+}
+
+// retryAfterDelay parses a Retry-After header in either delta-seconds or
+// HTTP-date form (RFC 7231 §7.1.3). It reports ok=false if the header is
+// absent or unparsable so the caller falls back to computed backoff.
+func retryAfterDelay(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// isRetryableError classifies transport-level failures. DNS errors,
+// connection-refused, TLS handshake failures, and timeouts are usually
+// transient and worth retrying; a canceled or deadline-exceeded context
+// means the caller gave up, so retrying would just burn the remaining
+// MaxElapsed budget on a request nobody is waiting for anymore.
+func isRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// sleepCtx sleeps for d, returning false early (without finishing the sleep)
+// if ctx is canceled first, so a retry loop stops immediately when the
+// caller gives up rather than waiting out the remaining backoff.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// generateUUIDv4 returns a random RFC 4122 version 4 UUID, used as the
+// Idempotency-Key for non-GET requests: it's generated once per logical
+// call and reused across retries, so a retried POST/PUT/DELETE is
+// deduplicated by the server instead of applied twice.
+func generateUUIDv4() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_NextDelay_StaysWithinBounds(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, JitterFactor: 3.0}
+
+	prev := time.Duration(0)
+	for i := 0; i < 200; i++ {
+		d := p.nextDelay(prev)
+		if d < p.BaseDelay {
+			t.Fatalf("attempt %d: delay %v below BaseDelay %v", i, d, p.BaseDelay)
+		}
+		if d > p.MaxDelay {
+			t.Fatalf("attempt %d: delay %v above MaxDelay %v", i, d, p.MaxDelay)
+		}
+		prev = d
+	}
+}
+
+func TestRetryPolicy_NextDelay_CapsAtMaxDelay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond, JitterFactor: 1000}
+
+	// A large prev pushes the [BaseDelay, prev*JitterFactor) draw well past
+	// MaxDelay; nextDelay must still clamp the result.
+	for i := 0; i < 50; i++ {
+		if d := p.nextDelay(time.Second); d > p.MaxDelay {
+			t.Fatalf("delay %v exceeds MaxDelay %v", d, p.MaxDelay)
+		}
+	}
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		wantOK  bool
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{"empty header", "", false, 0, 0},
+		{"delta seconds", "5", true, 5 * time.Second, 5 * time.Second},
+		{"negative delta seconds rejected", "-5", false, 0, 0},
+		{"zero delta seconds", "0", true, 0, 0},
+		{"http-date in the future", time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), true, 8 * time.Second, 10 * time.Second},
+		{"http-date in the past clamps to zero", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), true, 0, 0},
+		{"unparsable header", "not-a-date", false, 0, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d, ok := retryAfterDelay(tc.header)
+			if ok != tc.wantOK {
+				t.Fatalf("retryAfterDelay(%q) ok = %v, want %v", tc.header, ok, tc.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if d < tc.wantMin || d > tc.wantMax {
+				t.Errorf("retryAfterDelay(%q) = %v, want between %v and %v", tc.header, d, tc.wantMin, tc.wantMax)
+			}
+		})
+	}
+}
+
+type fakeNetError struct{ timeout bool }
+
+func (e fakeNetError) Error() string   { return "fake net error" }
+func (e fakeNetError) Timeout() bool   { return e.timeout }
+func (e fakeNetError) Temporary() bool { return true }
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"context canceled", context.Canceled, false},
+		{"context deadline exceeded", context.DeadlineExceeded, false},
+		{"context canceled wrapped with %w", fmt.Errorf("request failed: %w", context.Canceled), false},
+		{"net.Error", fakeNetError{timeout: true}, true},
+		{"plain error", errors.New("boom"), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isRetryableError(tc.err); got != tc.want {
+				t.Errorf("isRetryableError(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+var _ net.Error = fakeNetError{}
+
+func TestSleepCtx_ReturnsFalseOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if sleepCtx(ctx, time.Second) {
+		t.Error("expected sleepCtx to return false for an already-canceled context")
+	}
+}
+
+func TestSleepCtx_SleepsOutDuration(t *testing.T) {
+	start := time.Now()
+	if !sleepCtx(context.Background(), 10*time.Millisecond) {
+		t.Fatal("expected sleepCtx to return true")
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("sleepCtx returned after only %v, want >= 10ms", elapsed)
+	}
+}
+
+func TestGenerateUUIDv4_Format(t *testing.T) {
+	re := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	seen := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		id := generateUUIDv4()
+		if !re.MatchString(id) {
+			t.Fatalf("generateUUIDv4() = %q, does not match RFC 4122 v4 shape", id)
+		}
+		if seen[id] {
+			t.Fatalf("generateUUIDv4() produced a duplicate: %q", id)
+		}
+		seen[id] = true
+	}
+}
@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Confirm prompts the user with a y/N question on stdout/stdin and reports
+// whether they answered yes. It's meant for destructive commands (key
+// revoke/rotate, profile remove, ...) that take a --yes flag to skip the
+// prompt for scripts; callers check that flag themselves before calling
+// Confirm so non-interactive runs never block on stdin.
+func Confirm(prompt string) bool {
+	fmt.Printf("%s [y/N]: ", prompt)
+	reader := bufio.NewReader(os.Stdin)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"errors"
+	"os"
+
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// Execute runs root and translates whatever error comes back into a
+// structured output.Printer error plus the matching process exit code: a
+// *StatusError carries its own code, anything else is treated as a system
+// error. Every command whose RunE returns an error instead of printing and
+// exiting itself gets the same behavior for free; runLogin still calls
+// os.Exit directly when its interactive prompt fails to run.
+//
+// The --json flag is read from root only after root.Execute() returns, not
+// passed in by the caller: cobra doesn't parse flags until Execute runs, so
+// a bool snapshotted at the call site would always be the pre-parse zero
+// value. log.Init(verbose, jsonOutput) avoids the same trap by reading the
+// flags from a cobra.OnInitialize callback, which also runs after parsing.
+func Execute(root *cobra.Command) {
+	err := root.Execute()
+	if err == nil {
+		return
+	}
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		statusErr = SystemError(err)
+	}
+
+	jsonMode, _ := root.PersistentFlags().GetBool("json")
+	output.New(jsonMode).PrintError(statusErr.Err, statusErr.Code, "")
+	os.Exit(statusErr.Status)
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"golang.org/x/term"
+)
+
+// managementAnnotation marks a subcommand as belonging to the "Management
+// Commands" group in grouped help (config, debug, profile, ...) instead of
+// the default "Commands" group (version, login, status, ...).
+const managementAnnotation = "commandType"
+const managementValue = "management"
+
+// MarkManagement tags cmd so the grouped help/usage templates list it under
+// "Management Commands" rather than plain "Commands".
+func MarkManagement(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[managementAnnotation] = managementValue
+}
+
+func isManagement(cmd *cobra.Command) bool {
+	return cmd.Annotations[managementAnnotation] == managementValue
+}
+
+func hasManagementSubCommands(cmd *cobra.Command) bool {
+	for _, c := range cmd.Commands() {
+		if c.IsAvailableCommand() && isManagement(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func managementSubCommands(cmd *cobra.Command) []*cobra.Command {
+	var out []*cobra.Command
+	for _, c := range cmd.Commands() {
+		if c.IsAvailableCommand() && isManagement(c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func operationSubCommands(cmd *cobra.Command) []*cobra.Command {
+	var out []*cobra.Command
+	for _, c := range cmd.Commands() {
+		if c.IsAvailableCommand() && !isManagement(c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// terminalWidth returns the detected terminal width, falling back to 80
+// columns when stdout isn't a TTY (redirected output, CI logs, etc).
+func terminalWidth() int {
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		return w
+	}
+	return 80
+}
+
+func wrappedFlagUsages(f *flag.FlagSet) string {
+	return f.FlagUsagesWrapped(terminalWidth() - 1)
+}
+
+var usageTemplate = `Usage:{{if .Runnable}}
+  {{.UseLine}}{{end}}{{if .HasAvailableSubCommands}}
+  {{.CommandPath}} [command]{{end}}{{if gt (len .Aliases) 0}}
+
+Aliases:
+  {{.NameAndAliases}}{{end}}{{if .HasExample}}
+
+Examples:
+{{.Example}}{{end}}{{if .HasAvailableSubCommands}}{{if hasManagementSubCommands .}}
+
+Management Commands:{{range managementSubCommands .}}
+  {{rpad .Name .NamePadding}} {{.Short}}{{end}}{{end}}
+
+Commands:{{range operationSubCommands .}}
+  {{rpad .Name .NamePadding}} {{.Short}}{{end}}{{end}}{{if .HasAvailableLocalFlags}}
+
+Flags:
+{{wrappedFlagUsages .LocalFlags | trimTrailingWhitespaces}}{{end}}{{if .HasAvailableInheritedFlags}}
+
+Global Flags:
+{{wrappedFlagUsages .InheritedFlags | trimTrailingWhitespaces}}{{end}}{{if .HasHelpSubCommands}}
+
+Additional help topics:{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
+  {{rpad .CommandPath .CommandPathPadding}} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableSubCommands}}
+
+Use "{{.CommandPath}} [command] --help" for more information about a command.{{end}}
+`
+
+// SetupRootCommand installs the shared usage/help templates, flag-error
+// handling, and grouped help on root. It should be called once, from the
+// root command's init, before any subcommands render help.
+func SetupRootCommand(root *cobra.Command) {
+	cobra.AddTemplateFunc("hasManagementSubCommands", hasManagementSubCommands)
+	cobra.AddTemplateFunc("managementSubCommands", managementSubCommands)
+	cobra.AddTemplateFunc("operationSubCommands", operationSubCommands)
+	cobra.AddTemplateFunc("wrappedFlagUsages", wrappedFlagUsages)
+	cobra.AddTemplateFunc("rpad", rpad)
+	cobra.AddTemplateFunc("trimTrailingWhitespaces", strings.TrimRight)
+
+	root.SetUsageTemplate(usageTemplate)
+	root.SetFlagErrorFunc(flagErrorFunc)
+
+	// Execute is the single place that prints errors and picks an exit
+	// code; cobra's own "Error: ..." + usage dump would just duplicate that.
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+}
+
+func rpad(s string, padding int) string {
+	t := fmt.Sprintf("%%-%ds", padding)
+	return fmt.Sprintf(t, s)
+}
+
+// flagErrorFunc formats flag-parsing errors the way docker/kubectl do:
+// the error, then a pointer at --help, rather than cobra's default (which
+// dumps the full usage block on every typo).
+func flagErrorFunc(cmd *cobra.Command, err error) error {
+	if err == nil {
+		return nil
+	}
+	return NewStatusError(
+		fmt.Errorf("%w\nSee '%s --help'.", err, cmd.CommandPath()),
+		output.ExitUser,
+		"USER_ERROR",
+	)
+}
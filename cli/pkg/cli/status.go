@@ -0,0 +1,45 @@
+// Package cli provides the shared cobra plumbing used by the roset root
+// command: grouped help, a consistent flag-error format, and a single
+// Execute path that turns any command's error into the right process exit
+// code and a structured output.Printer error, instead of each command
+// deciding for itself whether (and how) to call os.Exit.
+package cli
+
+import (
+	"github.com/roset-dev/roset/monorepo/cli/pkg/output"
+)
+
+// StatusError pairs an error with the process exit code and output.Error
+// code it should produce. Commands that need a specific exit status (auth
+// failure, user error, system error) return one of these from RunE instead
+// of calling os.Exit directly; Execute turns them into the exit status.
+type StatusError struct {
+	Err    error
+	Status int    // e.g. output.ExitUser
+	Code   string // e.g. "USER_ERROR", echoed in the JSON error payload
+}
+
+func (e *StatusError) Error() string { return e.Err.Error() }
+
+func (e *StatusError) Unwrap() error { return e.Err }
+
+// NewStatusError wraps err with the exit code and output.Error code Execute
+// should use when it reaches the top of the command tree.
+func NewStatusError(err error, status int, code string) *StatusError {
+	return &StatusError{Err: err, Status: status, Code: code}
+}
+
+// UserError is shorthand for NewStatusError with output.ExitUser.
+func UserError(err error) *StatusError {
+	return NewStatusError(err, output.ExitUser, "USER_ERROR")
+}
+
+// SystemError is shorthand for NewStatusError with output.ExitSystem.
+func SystemError(err error) *StatusError {
+	return NewStatusError(err, output.ExitSystem, "SYSTEM_ERROR")
+}
+
+// AuthError is shorthand for NewStatusError with output.ExitAuth.
+func AuthError(err error) *StatusError {
+	return NewStatusError(err, output.ExitAuth, "AUTH_ERROR")
+}
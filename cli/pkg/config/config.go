@@ -1,24 +1,69 @@
 package config
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"time"
 
+	"github.com/roset-dev/roset/monorepo/cli/pkg/keychain"
+	"github.com/roset-dev/roset/monorepo/cli/pkg/oauth"
 	"github.com/spf13/viper"
 )
 
-// Config holds all CLI configuration with explicit types.
+// Config holds all CLI configuration with explicit types. APIURL, APIKey,
+// and CredentialHelper always reflect the active profile (Profile) — kept
+// in sync by Init/UseProfile/saveProfile — so the rest of the CLI
+// (api.Client, mount.go, status.go, doctor.go, ...) can keep reading them
+// directly without knowing profiles exist.
 type Config struct {
-	APIURL  string `mapstructure:"api_url"`
-	APIKey  string `mapstructure:"api_key"`
-	Profile string `mapstructure:"profile"`
-	Debug   bool   `mapstructure:"debug"`
+	APIURL              string                  `mapstructure:"api_url"`
+	APIKey              string                  `mapstructure:"api_key"`
+	Profile             string                  `mapstructure:"profile"`
+	Debug               bool                    `mapstructure:"debug"`
+	RedactRulesPath     string                  `mapstructure:"redact_rules_path"`
+	CredentialHelper    string                  `mapstructure:"credential_helper"`
+	CredentialBackend   string                  `mapstructure:"credential_backend"`
+	LoginTimeoutSeconds int                     `mapstructure:"login_timeout_seconds"`
+	Profiles            map[string]ProfileEntry `mapstructure:"profiles"`
+}
+
+// ProfileEntry is one named profile's stored settings, as managed by
+// `roset profile` and `roset login --profile`.
+type ProfileEntry struct {
+	APIURL           string `mapstructure:"api_url"`
+	APIKey           string `mapstructure:"api_key"`
+	CredentialHelper string `mapstructure:"credential_helper"`
+
+	// RefreshToken and TokenExpiresAt are only set for a profile created by
+	// `roset login --method device`: an access token obtained that way
+	// expires, so RefreshFunc uses RefreshToken to silently mint a new one.
+	// A pasted API key (the common case) leaves both empty and never
+	// expires as far as the CLI knows. Unlike APIKey, RefreshToken isn't
+	// routed through the OS keychain yet - the docker-credential-helpers
+	// envelope only holds one secret per ServerURL, which APIKey already
+	// uses.
+	RefreshToken   string `mapstructure:"refresh_token"`
+	TokenExpiresAt string `mapstructure:"token_expires_at"` // RFC3339; empty if the token doesn't expire or its lifetime is unknown
 }
 
 // Defaults
 const (
-	DefaultAPIURL = "https://api.roset.dev"
+	DefaultAPIURL  = "https://api.roset.dev"
+	DefaultProfile = "default"
+
+	// DefaultLoginTimeoutSeconds bounds how long `roset login` waits on a
+	// single credential-validation attempt before giving up on it as a
+	// transient failure; see LoginTimeout.
+	DefaultLoginTimeoutSeconds = 10
+
+	// keychainPlaceholder is written to a profile's api_key in config.yaml
+	// when the real secret lives in the OS credential store instead, so the
+	// file never carries the plaintext key once a helper is available.
+	keychainPlaceholder = "<stored-in-os-keychain>"
 )
 
 var Cfg Config
@@ -40,8 +85,11 @@ func Init() error {
 
 	// Set defaults
 	viper.SetDefault("api_url", DefaultAPIURL)
-	viper.SetDefault("profile", "default")
+	viper.SetDefault("profile", DefaultProfile)
 	viper.SetDefault("debug", false)
+	viper.SetDefault("redact_rules_path", filepath.Join(configPath, "redact.yaml"))
+	viper.SetDefault("login_timeout_seconds", DefaultLoginTimeoutSeconds)
+	viper.SetDefault("credential_backend", "")
 
 	// Environment variable binding (ROSET_API_KEY, ROSET_API_URL, etc.)
 	viper.SetEnvPrefix("ROSET")
@@ -58,12 +106,157 @@ func Init() error {
 		return fmt.Errorf("unable to decode config: %w", err)
 	}
 
+	// ROSET_PROFILE overrides the active profile without touching
+	// config.yaml, so CI can pin a profile (e.g. "staging") per-job without
+	// every step passing --profile.
+	if envProfile := os.Getenv("ROSET_PROFILE"); envProfile != "" {
+		Cfg.Profile = envProfile
+	}
+
+	if Cfg.Profile == "" {
+		Cfg.Profile = DefaultProfile
+	}
+	if Cfg.Profiles == nil {
+		Cfg.Profiles = map[string]ProfileEntry{}
+	}
+	// Config files written before `roset profile` existed carry api_url/
+	// api_key/credential_helper at the top level with no profiles map.
+	// Treat that as a single profile named "default" so existing installs
+	// keep working unchanged.
+	if _, ok := Cfg.Profiles[Cfg.Profile]; !ok {
+		Cfg.Profiles[Cfg.Profile] = ProfileEntry{
+			APIURL:           Cfg.APIURL,
+			APIKey:           Cfg.APIKey,
+			CredentialHelper: Cfg.CredentialHelper,
+		}
+	}
+
+	return resolveActiveProfile()
+}
+
+// resolveActiveProfile copies the active profile's settings into the
+// top-level Cfg fields the rest of the CLI reads, resolving a keychain
+// placeholder back into the real API key along the way.
+func resolveActiveProfile() error {
+	active := Cfg.Profiles[Cfg.Profile]
+
+	Cfg.APIURL = active.APIURL
+	if Cfg.APIURL == "" {
+		Cfg.APIURL = DefaultAPIURL
+	}
+	Cfg.APIKey = active.APIKey
+	Cfg.CredentialHelper = active.CredentialHelper
+
+	// ROSET_API_URL/ROSET_API_KEY still win over the profile's stored values.
+	if env := os.Getenv("ROSET_API_URL"); env != "" {
+		Cfg.APIURL = env
+	}
+	if env := os.Getenv("ROSET_API_KEY"); env != "" {
+		Cfg.APIKey = env
+		return nil
+	}
+
+	if Cfg.CredentialHelper != "" && Cfg.APIKey == keychainPlaceholder {
+		key, err := keychain.NewHelper(Cfg.CredentialHelper).Get(keychain.ProfileServerURL(Cfg.Profile))
+		if err != nil {
+			// Don't leave the placeholder in Cfg.APIKey: callers (api.Client,
+			// mount.go) would otherwise treat it as the literal key, turning
+			// a local "helper unavailable" error into a confusing remote 401.
+			Cfg.APIKey = ""
+			return fmt.Errorf("reading API key for profile %q from %s credential helper: %w", Cfg.Profile, Cfg.CredentialHelper, err)
+		}
+		Cfg.APIKey = key
+	}
+
 	return nil
 }
 
+// eraseStoredCredential removes profile's entry from helperName's credential
+// store, if any. A helper that's no longer on PATH is treated the same as
+// having nothing to erase — doctor.go already flags a configured-but-
+// missing helper separately, and failing the caller's operation (logout,
+// profile remove) over it would leave the user stuck unable to clear local
+// state for a credential store they can no longer reach anyway.
+func eraseStoredCredential(helperName, profile string) error {
+	if helperName == "" {
+		return nil
+	}
+	h := keychain.NewHelper(helperName)
+	if !h.Available() {
+		return nil
+	}
+	return h.Erase(keychain.ProfileServerURL(profile))
+}
+
+// keychainBackend returns the OS credential helper to use for storing an
+// API key, or nil to store it in plaintext in config.yaml instead. The
+// backend is Cfg.CredentialBackend if `roset config credential-backend` set
+// one, otherwise the platform default (see keychain.DefaultBackendName); an
+// operator who doesn't want keychain storage can disable it either by
+// setting the backend to "file" or by leaving no matching helper on PATH.
+func keychainBackend() *keychain.Helper {
+	name := Cfg.CredentialBackend
+	if name == "" {
+		name = keychain.DefaultBackendName()
+	}
+	if name == "" || name == "file" {
+		return nil
+	}
+	h := keychain.NewHelper(name)
+	if !h.Available() {
+		return nil
+	}
+	return h
+}
+
+// CredentialBackendName returns the backend `roset login`/`roset profile`
+// currently use to store API keys: the override `roset config
+// credential-backend` set, the platform default, or "file" if neither
+// applies (no OS keychain support on this platform, or the operator forced
+// plaintext storage). It does not check that the named helper is on PATH;
+// when it isn't, keychainBackend falls back to plaintext storage anyway.
+func CredentialBackendName() string {
+	if Cfg.CredentialBackend != "" {
+		return Cfg.CredentialBackend
+	}
+	if name := keychain.DefaultBackendName(); name != "" {
+		return name
+	}
+	return "file"
+}
+
+// SetCredentialBackend overrides the backend future `roset login`/`roset
+// profile` saves use: either "file" to store API keys in plaintext in
+// config.yaml, or the name of a docker-credential-helpers backend that
+// must already be installed on PATH. It only takes effect for keys saved
+// after the override - run 'roset login' again to move an already-stored
+// key onto the new backend.
+func SetCredentialBackend(name string) error {
+	if name != "file" && !keychain.NewHelper(name).Available() {
+		return fmt.Errorf("credential helper %q is not installed (docker-credential-%s not found on PATH)", name, name)
+	}
+	Cfg.CredentialBackend = name
+	return persist()
+}
+
 // Resolve applies flag overrides to the config.
 // This implements: Flags > Env > File > Defaults
-func Resolve(apiKey, apiURL string, debug bool) {
+//
+// profile, if set, switches the active profile for this invocation only
+// (it is not persisted to config.yaml, unlike 'roset profile use'), taking
+// precedence over ROSET_PROFILE and config.yaml's profile, per --profile's
+// doc comment in root.go.
+func Resolve(apiKey, apiURL, profile string, debug bool) error {
+	if profile != "" && profile != Cfg.Profile {
+		if _, ok := Cfg.Profiles[profile]; !ok {
+			return fmt.Errorf("profile %q does not exist; create it with 'roset profile create %s'", profile, profile)
+		}
+		Cfg.Profile = profile
+		if err := resolveActiveProfile(); err != nil {
+			return err
+		}
+	}
+
 	if apiKey != "" {
 		Cfg.APIKey = apiKey
 	}
@@ -73,6 +266,7 @@ func Resolve(apiKey, apiURL string, debug bool) {
 	if debug {
 		Cfg.Debug = true
 	}
+	return nil
 }
 
 // GetAPIURL returns the effective API URL.
@@ -83,46 +277,275 @@ func GetAPIURL() string {
 	return DefaultAPIURL
 }
 
-// Save persists the current configuration to disk.
-func Save(url, key string) error {
-	viper.Set("api_key", key)
-	if url != "" && url != DefaultAPIURL {
-		viper.Set("api_url", url)
+// LoginTimeout returns how long a single `roset login` credential-
+// validation attempt should wait before timing out, from config.yaml's
+// login_timeout_seconds (overridable per-invocation by login's --timeout
+// flag).
+func LoginTimeout() time.Duration {
+	sec := Cfg.LoginTimeoutSeconds
+	if sec <= 0 {
+		sec = DefaultLoginTimeoutSeconds
 	}
+	return time.Duration(sec) * time.Second
+}
 
-	home, err := os.UserHomeDir()
-	if err != nil {
+// CurrentProfile returns the name of the active profile.
+func CurrentProfile() string {
+	if Cfg.Profile == "" {
+		return DefaultProfile
+	}
+	return Cfg.Profile
+}
+
+// ProfileNames returns the configured profile names, sorted, for `roset
+// profile list`.
+func ProfileNames() []string {
+	names := make([]string, 0, len(Cfg.Profiles))
+	for name := range Cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ProfileEntryByName returns the stored settings for name and whether it
+// exists.
+func ProfileEntryByName(name string) (ProfileEntry, bool) {
+	entry, ok := Cfg.Profiles[name]
+	return entry, ok
+}
+
+// CreateProfile adds a new, credential-less profile pointed at apiURL.
+// `roset login --profile name` is how a profile gets its API key.
+func CreateProfile(name, apiURL string) error {
+	if name == "" {
+		return fmt.Errorf("profile name cannot be empty")
+	}
+	if _, exists := Cfg.Profiles[name]; exists {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	Cfg.Profiles[name] = ProfileEntry{APIURL: apiURL}
+	return persist()
+}
+
+// UseProfile switches the active profile to name, resolving its API key
+// (including from the OS keychain) into Cfg for the rest of the CLI. The
+// switch is only persisted to disk once resolution succeeds, so a profile
+// whose key can't currently be read (e.g. a locked keychain) doesn't get
+// stuck as the active profile on a failed switch.
+func UseProfile(name string) error {
+	if _, ok := Cfg.Profiles[name]; !ok {
+		return fmt.Errorf("profile %q does not exist; create it with 'roset profile create %s'", name, name)
+	}
+
+	previous := Cfg.Profile
+	Cfg.Profile = name
+	if err := resolveActiveProfile(); err != nil {
+		Cfg.Profile = previous
+		_ = resolveActiveProfile()
 		return err
 	}
 
-	configDir := filepath.Join(home, ".roset")
-	if err := os.MkdirAll(configDir, 0700); err != nil {
+	return persist()
+}
+
+// RemoveProfile deletes a profile and its stored credential. Removing the
+// active profile falls back to "default".
+func RemoveProfile(name string) error {
+	entry, ok := Cfg.Profiles[name]
+	if !ok {
+		return fmt.Errorf("profile %q does not exist", name)
+	}
+	if name == DefaultProfile {
+		return fmt.Errorf("cannot remove the %q profile", DefaultProfile)
+	}
+
+	if err := eraseStoredCredential(entry.CredentialHelper, name); err != nil {
+		return fmt.Errorf("removing credential from %s: %w", entry.CredentialHelper, err)
+	}
+	delete(Cfg.Profiles, name)
+
+	if Cfg.Profile == name {
+		Cfg.Profile = DefaultProfile
+		if _, ok := Cfg.Profiles[DefaultProfile]; !ok {
+			Cfg.Profiles[DefaultProfile] = ProfileEntry{APIURL: DefaultAPIURL}
+		}
+	}
+
+	if err := persist(); err != nil {
 		return err
 	}
+	return resolveActiveProfile()
+}
 
-	configFile := filepath.Join(configDir, "config.yaml")
-	if err := viper.WriteConfigAs(configFile); err != nil {
+// Save persists the API URL/key for the active profile. `roset login` and
+// `roset keys create` call SaveToProfile instead.
+func Save(url, key string) error {
+	return saveProfile(CurrentProfile(), url, key)
+}
+
+// SaveToProfile behaves like Save but targets an explicit profile instead
+// of the active one, and switches to it. `roset login --profile <name>`
+// uses this so logging into a named profile also makes it active.
+func SaveToProfile(name, url, key string) error {
+	return saveProfile(name, url, key)
+}
+
+// saveProfile stores url/key for the named profile, preferring an OS
+// credential helper the same way Save always has, makes it the active
+// profile, and persists the result to config.yaml.
+func saveProfile(name, url, key string) error {
+	if url == "" {
+		url = DefaultAPIURL
+	}
+	previous := Cfg.Profiles[name]
+
+	entry := ProfileEntry{APIURL: url}
+	if backend := keychainBackend(); backend != nil {
+		if err := backend.Store(keychain.ProfileServerURL(name), key); err == nil {
+			entry.APIKey = keychainPlaceholder
+			entry.CredentialHelper = backend.Name
+		} else {
+			entry.APIKey = key
+		}
+	} else {
+		entry.APIKey = key
+	}
+
+	// If this profile previously stored its key under a different helper
+	// (or a helper at all), clean up the stale entry so a rotated/replaced
+	// key doesn't linger in the OS credential store.
+	if previous.CredentialHelper != "" && previous.CredentialHelper != entry.CredentialHelper {
+		_ = eraseStoredCredential(previous.CredentialHelper, name)
+	}
+
+	if Cfg.Profiles == nil {
+		Cfg.Profiles = map[string]ProfileEntry{}
+	}
+	Cfg.Profiles[name] = entry
+	Cfg.Profile = name
+
+	if err := persist(); err != nil {
 		return err
 	}
+	return resolveActiveProfile()
+}
 
-	// Harden permissions to 0600 (owner read/write only)
-	return os.Chmod(configFile, 0600)
+// SaveDeviceToken persists the result of a completed OAuth device-
+// authorization grant (see pkg/oauth): the access token is stored under
+// name the same way SaveToProfile stores a pasted API key (OS keychain
+// when available), with the refresh token and expiry kept alongside it in
+// config.yaml so RefreshFunc can silently renew it later.
+func SaveDeviceToken(name, url, accessToken, refreshToken string, expiresIn int) error {
+	if err := saveProfile(name, url, accessToken); err != nil {
+		return err
+	}
+
+	entry := Cfg.Profiles[name]
+	entry.RefreshToken = refreshToken
+	entry.TokenExpiresAt = ""
+	if expiresIn > 0 {
+		entry.TokenExpiresAt = time.Now().Add(time.Duration(expiresIn) * time.Second).Format(time.RFC3339)
+	}
+	Cfg.Profiles[name] = entry
+
+	return persist()
+}
+
+// RefreshFunc returns a callback suitable for api.Client.RefreshFunc that
+// exchanges profile's stored refresh token for a new access token via the
+// device flow's token endpoint, persisting the result the same way
+// SaveDeviceToken did originally. It errors out synchronously if the
+// profile has no refresh token (e.g. it was logged into with a pasted API
+// key), so a 401 against such a profile surfaces as the original error
+// instead of a confusing refresh failure.
+func RefreshFunc(profile string) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		entry, ok := Cfg.Profiles[profile]
+		if !ok || entry.RefreshToken == "" {
+			return "", fmt.Errorf("profile %q has no refresh token; run 'roset login --method device' again", profile)
+		}
+
+		tok, err := oauth.RefreshAccessToken(ctx, http.DefaultClient, entry.APIURL, entry.RefreshToken)
+		if err != nil {
+			return "", err
+		}
+
+		refreshToken := tok.RefreshToken
+		if refreshToken == "" {
+			refreshToken = entry.RefreshToken // server didn't rotate it
+		}
+		if err := SaveDeviceToken(profile, entry.APIURL, tok.AccessToken, refreshToken, tok.ExpiresIn); err != nil {
+			return "", err
+		}
+		return tok.AccessToken, nil
+	}
 }
 
-// Clear removes stored credentials.
+// Clear removes the active profile's stored API key: the keychain entry if
+// it has one, and the entry in config.yaml. Unlike deleting config.yaml
+// outright, this leaves the file and any other profiles in place.
 func Clear() error {
-	viper.Set("api_key", "")
+	name := CurrentProfile()
+	entry := Cfg.Profiles[name]
+
+	if err := eraseStoredCredential(entry.CredentialHelper, name); err != nil {
+		return fmt.Errorf("removing credential from %s: %w", entry.CredentialHelper, err)
+	}
+
+	entry.APIKey = ""
+	entry.CredentialHelper = ""
+	Cfg.Profiles[name] = entry
+	Cfg.APIKey = ""
+	Cfg.CredentialHelper = ""
+
+	return persist()
+}
+
+// persist writes Cfg's profile and profiles map to config.yaml, mirroring
+// the active profile at the top level too so a config.yaml written by a
+// profile-aware CLI can still be read by tooling that only knows the
+// pre-profile flat fields.
+func persist() error {
+	viper.Set("profile", Cfg.Profile)
+
+	profiles := make(map[string]interface{}, len(Cfg.Profiles))
+	for name, p := range Cfg.Profiles {
+		profiles[name] = map[string]interface{}{
+			"api_url":           p.APIURL,
+			"api_key":           p.APIKey,
+			"credential_helper": p.CredentialHelper,
+			"refresh_token":     p.RefreshToken,
+			"token_expires_at":  p.TokenExpiresAt,
+		}
+	}
+	viper.Set("profiles", profiles)
+
+	active := Cfg.Profiles[Cfg.Profile]
+	viper.Set("api_url", active.APIURL)
+	viper.Set("api_key", active.APIKey)
+	viper.Set("credential_helper", active.CredentialHelper)
+	viper.Set("credential_backend", Cfg.CredentialBackend)
 
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return err
 	}
 
-	configFile := filepath.Join(home, ".roset", "config.yaml")
+	configDir := filepath.Join(home, ".roset")
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		return err
+	}
+
+	configFile := filepath.Join(configDir, "config.yaml")
 	if err := viper.WriteConfigAs(configFile); err != nil {
 		return err
 	}
 
-	Cfg.APIKey = ""
-	return nil
+	// Harden permissions to 0600 (owner read/write only)
+	return os.Chmod(configFile, 0600)
 }
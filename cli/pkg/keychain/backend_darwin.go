@@ -0,0 +1,9 @@
+//go:build darwin
+
+package keychain
+
+// DefaultBackendName returns the docker-credential-helpers backend name
+// for macOS Keychain.
+func DefaultBackendName() string {
+	return "osxkeychain"
+}
@@ -0,0 +1,9 @@
+//go:build linux
+
+package keychain
+
+// DefaultBackendName returns the docker-credential-helpers backend name
+// for the freedesktop Secret Service (GNOME Keyring, KWallet via ksecrets, ...).
+func DefaultBackendName() string {
+	return "secretservice"
+}
@@ -0,0 +1,10 @@
+//go:build !darwin && !linux && !windows
+
+package keychain
+
+// DefaultBackendName returns "" on platforms with no known built-in
+// credential store, meaning callers should fall back to the plaintext
+// config file unless the operator sets one explicitly.
+func DefaultBackendName() string {
+	return ""
+}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package keychain
+
+// DefaultBackendName returns the docker-credential-helpers backend name
+// for the Windows Credential Manager.
+func DefaultBackendName() string {
+	return "wincred"
+}
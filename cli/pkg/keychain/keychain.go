@@ -0,0 +1,145 @@
+// Package keychain stores the Roset API key in the OS credential store
+// instead of in plaintext in ~/.roset/config.yaml. It speaks the same
+// protocol as Docker's credential helpers (docker-credential-helpers): a
+// small external binary on PATH named docker-credential-<backend> that
+// reads/writes a JSON envelope on stdin/stdout for the "store", "get", and
+// "erase" verbs. Reusing that protocol means the CLI gets real OS keychain
+// support (macOS Keychain, Windows Credential Manager, the Secret Service
+// on Linux) for free from whichever helper the operator already has
+// installed, rather than us bundling our own OS-specific credential code.
+package keychain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// serverURLPrefix namespaces every credential the CLI stores, analogous to
+// the registry hostname Docker stores credentials against.
+const serverURLPrefix = "roset-cli"
+
+// ProfileServerURL returns the ServerURL a given roset profile's API key is
+// stored under. Namespacing by profile keeps multiple `roset profile`
+// entries from colliding in the same OS keychain.
+func ProfileServerURL(profile string) string {
+	if profile == "" {
+		return serverURLPrefix
+	}
+	return serverURLPrefix + ":" + profile
+}
+
+// ErrNotFound is returned by Get when no credential is stored for the given
+// ServerURL.
+var ErrNotFound = errors.New("keychain: credential not found")
+
+// ErrUnavailable is returned when the backend's helper binary can't be
+// found on PATH; callers should fall back to the plaintext config file.
+var ErrUnavailable = errors.New("keychain: credential helper not installed")
+
+// credentials is the JSON envelope docker-credential-helpers reads and
+// writes on stdin/stdout.
+type credentials struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+// Backend stores and retrieves API keys, keyed by ServerURL, from a single
+// OS credential store. It's an interface so callers aren't tied to shelling
+// out to a real helper binary.
+type Backend interface {
+	Store(serverURL, apiKey string) error
+	Get(serverURL string) (apiKey string, err error)
+	Erase(serverURL string) error
+}
+
+// Helper is a Backend that shells out to a docker-credential-helpers-style
+// binary named "docker-credential-<name>".
+type Helper struct {
+	Name string
+}
+
+// NewHelper returns a Helper for the named backend (e.g. "osxkeychain",
+// "wincred", "secretservice", "pass") without checking it's installed.
+func NewHelper(name string) *Helper {
+	return &Helper{Name: name}
+}
+
+func (h *Helper) binary() string {
+	return "docker-credential-" + h.Name
+}
+
+// Available reports whether the backend's helper binary is on PATH.
+func (h *Helper) Available() bool {
+	_, err := exec.LookPath(h.binary())
+	return err == nil
+}
+
+// Store saves apiKey under serverURL via the helper's "store" verb.
+func (h *Helper) Store(serverURL, apiKey string) error {
+	body, err := json.Marshal(credentials{ServerURL: serverURL, Username: "roset", Secret: apiKey})
+	if err != nil {
+		return err
+	}
+	_, err = h.run("store", body)
+	return err
+}
+
+// Get retrieves the API key stored under serverURL via the helper's "get"
+// verb. It returns ErrNotFound if the helper has nothing stored.
+func (h *Helper) Get(serverURL string) (string, error) {
+	out, err := h.run("get", []byte(serverURL))
+	if err != nil {
+		return "", err
+	}
+	var creds credentials
+	if err := json.Unmarshal(out, &creds); err != nil {
+		return "", fmt.Errorf("%s get: parsing response: %w", h.binary(), err)
+	}
+	return creds.Secret, nil
+}
+
+// Erase removes the credential stored under serverURL. It is a no-op (not
+// an error) if nothing was stored.
+func (h *Helper) Erase(serverURL string) error {
+	_, err := h.run("erase", []byte(serverURL))
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// run invokes the helper binary with verb on argv and the given payload on
+// stdin, the calling convention every docker-credential-helpers backend
+// implements.
+func (h *Helper) run(verb string, stdin []byte) ([]byte, error) {
+	path, err := exec.LookPath(h.binary())
+	if err != nil {
+		return nil, ErrUnavailable
+	}
+
+	cmd := exec.Command(path, verb)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = strings.TrimSpace(stdout.String())
+		}
+		if (verb == "get" || verb == "erase") && strings.Contains(msg, "credentials not found") {
+			return nil, ErrNotFound
+		}
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("%s %s: %s", h.binary(), verb, msg)
+	}
+	return stdout.Bytes(), nil
+}
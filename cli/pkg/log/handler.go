@@ -0,0 +1,85 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var dimStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+// textHandler is a minimal slog.Handler that renders records with the CLI's
+// existing lipgloss palette instead of slog's default key=value dump, so
+// --verbose output matches the look of everything else the CLI prints.
+type textHandler struct {
+	out   io.Writer
+	level slog.Level
+	attrs []slog.Attr
+}
+
+func newTextHandler(w io.Writer, level slog.Level) *textHandler {
+	return &textHandler{out: w, level: level}
+}
+
+func (h *textHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *textHandler) Handle(_ context.Context, r slog.Record) error {
+	levelStyle, label := styleForLevel(r.Level)
+
+	var b strings.Builder
+	b.WriteString(dimStyle.Render(r.Time.Format(time.RFC3339)))
+	b.WriteString(" ")
+	b.WriteString(levelStyle.Render(label))
+	b.WriteString(" ")
+	b.WriteString(r.Message)
+
+	writeAttr := func(a slog.Attr) {
+		if a.Key == "" {
+			return
+		}
+		fmt.Fprintf(&b, " %s%v", dimStyle.Render(a.Key+"="), a.Value.Any())
+	}
+	for _, a := range h.attrs {
+		writeAttr(a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		writeAttr(a)
+		return true
+	})
+	b.WriteString("\n")
+
+	_, err := io.WriteString(h.out, b.String())
+	return err
+}
+
+func (h *textHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	merged := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	merged = append(merged, h.attrs...)
+	merged = append(merged, attrs...)
+	return &textHandler{out: h.out, level: h.level, attrs: merged}
+}
+
+func (h *textHandler) WithGroup(_ string) slog.Handler {
+	// The CLI never nests attrs into groups, so this is a no-op passthrough.
+	return h
+}
+
+func styleForLevel(level slog.Level) (lipgloss.Style, string) {
+	switch {
+	case level >= slog.LevelError:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("196")), "ERROR"
+	case level >= slog.LevelWarn:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("214")), "WARN"
+	case level >= slog.LevelInfo:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#4DA3FF")), "INFO"
+	default:
+		return dimStyle, "DEBUG"
+	}
+}
@@ -0,0 +1,67 @@
+// Package log is the Roset CLI's structured logging subsystem. It exposes a
+// package-level *slog.Logger configured from --verbose, --json, and the
+// ROSET_LOG_LEVEL environment variable: text mode renders with the CLI's
+// existing lipgloss palette, JSON mode emits one record per line for
+// machine consumption. Log output goes to stderr so it never contaminates
+// a command's stdout payload (plain text or --json).
+package log
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+)
+
+var logger = slog.New(newTextHandler(os.Stderr, slog.LevelInfo))
+
+// Init (re)configures the package-level logger. verbose drops the level to
+// Debug; jsonMode switches to one-JSON-object-per-line output. A recognized
+// ROSET_LOG_LEVEL environment variable wins over verbose (it sets only the
+// level, not the output format), so CI and debugging sessions can dial the
+// level without touching flags.
+func Init(verbose, jsonMode bool) {
+	level := slog.LevelInfo
+	if verbose {
+		level = slog.LevelDebug
+	}
+	if envLevel, ok := levelFromEnv(); ok {
+		level = envLevel
+	}
+
+	var handler slog.Handler
+	if jsonMode {
+		handler = slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: level})
+	} else {
+		handler = newTextHandler(os.Stderr, level)
+	}
+	logger = slog.New(handler)
+}
+
+func levelFromEnv() (slog.Level, bool) {
+	switch strings.ToUpper(strings.TrimSpace(os.Getenv("ROSET_LOG_LEVEL"))) {
+	case "DEBUG":
+		return slog.LevelDebug, true
+	case "INFO":
+		return slog.LevelInfo, true
+	case "WARN", "WARNING":
+		return slog.LevelWarn, true
+	case "ERROR":
+		return slog.LevelError, true
+	default:
+		return slog.LevelInfo, false
+	}
+}
+
+// Debug, Info, Warn, and Error log at the given level. args are alternating
+// key-value pairs, the same calling convention as slog.Logger.
+func Debug(msg string, args ...any) { logger.Debug(msg, args...) }
+func Info(msg string, args ...any)  { logger.Info(msg, args...) }
+func Warn(msg string, args ...any)  { logger.Warn(msg, args...) }
+func Error(msg string, args ...any) { logger.Error(msg, args...) }
+
+// With returns a logger scoped to the given key-value pairs, so a request ID
+// (or any other correlation key) can be attached once and reused across every
+// line logged for that request.
+func With(args ...any) *slog.Logger {
+	return logger.With(args...)
+}
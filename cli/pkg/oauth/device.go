@@ -0,0 +1,216 @@
+// Package oauth implements the RFC 8628 OAuth 2.0 device authorization
+// grant used by `roset login --method device`, so someone on a headless or
+// remote machine can authenticate in a browser on another device instead of
+// copy-pasting a long-lived API key.
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	deviceCodePath  = "/oauth/device/code"
+	tokenPath       = "/oauth/token"
+	deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+	clientID        = "roset-cli"
+)
+
+// ErrAccessDenied is returned by PollToken when the user rejects the
+// authorization request.
+var ErrAccessDenied = errors.New("authorization request was denied")
+
+// ErrExpired is returned by PollToken when the device code expires before
+// the user completes authorization.
+var ErrExpired = errors.New("device code expired before authorization completed")
+
+// DeviceCode is the server's response to a device authorization request:
+// what to show the user (UserCode, VerificationURI) and how to poll for the
+// result (DeviceCode, Interval, ExpiresIn).
+type DeviceCode struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// Token is a successful access/refresh token response.
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+}
+
+// tokenErrorResponse is how the token endpoint reports a pending or failed
+// poll, per RFC 8628 §3.5.
+type tokenErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// RequestDeviceCode starts a device-authorization grant against baseURL.
+func RequestDeviceCode(ctx context.Context, httpClient *http.Client, baseURL string) (*DeviceCode, error) {
+	form := url.Values{"client_id": {clientID}}
+	body, err := postForm(ctx, httpClient, baseURL+deviceCodePath, form)
+	if err != nil {
+		return nil, fmt.Errorf("requesting device code: %w", err)
+	}
+
+	var dc DeviceCode
+	if err := json.Unmarshal(body, &dc); err != nil {
+		return nil, fmt.Errorf("parsing device code response: %w", err)
+	}
+	if dc.Interval <= 0 {
+		dc.Interval = 5
+	}
+	return &dc, nil
+}
+
+// PollToken polls baseURL's token endpoint for dc until the user completes
+// or rejects authorization, the device code expires, or ctx is canceled. It
+// implements RFC 8628 §3.5's polling backoff: authorization_pending means
+// keep polling at the current interval, slow_down adds 5s to it, and
+// expired_token/access_denied are terminal.
+func PollToken(ctx context.Context, httpClient *http.Client, baseURL string, dc *DeviceCode) (*Token, error) {
+	interval := time.Duration(dc.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	var deadline time.Time
+	if dc.ExpiresIn > 0 {
+		deadline = time.Now().Add(time.Duration(dc.ExpiresIn) * time.Second)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return nil, ErrExpired
+		}
+
+		token, pollErr, err := pollOnce(ctx, httpClient, baseURL, dc.DeviceCode)
+		if err != nil {
+			return nil, err
+		}
+		if token != nil {
+			return token, nil
+		}
+
+		switch pollErr {
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += 5 * time.Second
+			continue
+		case "expired_token":
+			return nil, ErrExpired
+		case "access_denied":
+			return nil, ErrAccessDenied
+		default:
+			return nil, fmt.Errorf("unexpected token poll response: %q", pollErr)
+		}
+	}
+}
+
+// RefreshAccessToken exchanges refreshToken for a new access token (and,
+// if the server rotates them, a new refresh token), per RFC 6749 §6. It's
+// what api.Client.RefreshFunc calls once an access token issued by the
+// device flow expires; see config.RefreshFunc.
+func RefreshAccessToken(ctx context.Context, httpClient *http.Client, baseURL, refreshToken string) (*Token, error) {
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+		"client_id":     {clientID},
+	}
+	body, err := postForm(ctx, httpClient, baseURL+tokenPath, form)
+	if err != nil {
+		return nil, fmt.Errorf("refreshing access token: %w", err)
+	}
+
+	var tok Token
+	if err := json.Unmarshal(body, &tok); err != nil {
+		return nil, fmt.Errorf("parsing refresh response: %w", err)
+	}
+	return &tok, nil
+}
+
+// pollOnce makes a single poll of the token endpoint, returning either a
+// Token on success or the RFC 8628 error string ("authorization_pending",
+// "slow_down", ...) on a 4xx response with a recognized envelope.
+func pollOnce(ctx context.Context, httpClient *http.Client, baseURL, deviceCode string) (*Token, string, error) {
+	form := url.Values{
+		"grant_type":  {deviceGrantType},
+		"device_code": {deviceCode},
+		"client_id":   {clientID},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+tokenPath, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("polling token endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading token response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		var tok Token
+		if err := json.Unmarshal(body, &tok); err != nil {
+			return nil, "", fmt.Errorf("parsing token response: %w", err)
+		}
+		return &tok, "", nil
+	}
+
+	var tokErr tokenErrorResponse
+	if err := json.Unmarshal(body, &tokErr); err != nil || tokErr.Error == "" {
+		return nil, "", fmt.Errorf("token endpoint returned HTTP %d: %s", resp.StatusCode, body)
+	}
+	return nil, tokErr.Error, nil
+}
+
+// postForm POSTs an application/x-www-form-urlencoded body and returns the
+// response bytes, erroring out on a non-2xx status.
+func postForm(ctx context.Context, httpClient *http.Client, url string, form url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, body)
+	}
+	return body, nil
+}
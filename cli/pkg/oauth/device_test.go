@@ -0,0 +1,224 @@
+package oauth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPollOnce(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantToken  string
+		wantErrStr string
+		wantErr    bool
+	}{
+		{
+			name:      "success",
+			status:    http.StatusOK,
+			body:      `{"access_token":"at-1","refresh_token":"rt-1","expires_in":3600}`,
+			wantToken: "at-1",
+		},
+		{
+			name:       "authorization pending",
+			status:     http.StatusBadRequest,
+			body:       `{"error":"authorization_pending"}`,
+			wantErrStr: "authorization_pending",
+		},
+		{
+			name:       "slow down",
+			status:     http.StatusBadRequest,
+			body:       `{"error":"slow_down"}`,
+			wantErrStr: "slow_down",
+		},
+		{
+			name:       "expired token",
+			status:     http.StatusBadRequest,
+			body:       `{"error":"expired_token"}`,
+			wantErrStr: "expired_token",
+		},
+		{
+			name:       "access denied",
+			status:     http.StatusBadRequest,
+			body:       `{"error":"access_denied"}`,
+			wantErrStr: "access_denied",
+		},
+		{
+			name:    "malformed error envelope",
+			status:  http.StatusBadRequest,
+			body:    `not json`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			defer ts.Close()
+
+			tok, pollErr, err := pollOnce(context.Background(), ts.Client(), ts.URL, "device-code")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantToken != "" {
+				if tok == nil || tok.AccessToken != tc.wantToken {
+					t.Errorf("token = %+v, want AccessToken %q", tok, tc.wantToken)
+				}
+			}
+			if pollErr != tc.wantErrStr {
+				t.Errorf("pollErr = %q, want %q", pollErr, tc.wantErrStr)
+			}
+		})
+	}
+}
+
+func TestPollToken_SucceedsAfterPending(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if n < 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"authorization_pending"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"access_token":"at-1"}`))
+	}))
+	defer ts.Close()
+
+	dc := &DeviceCode{DeviceCode: "device-code", Interval: 1, ExpiresIn: 60}
+	tok, err := PollToken(context.Background(), ts.Client(), ts.URL, dc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tok.AccessToken != "at-1" {
+		t.Errorf("AccessToken = %q, want at-1", tok.AccessToken)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2 (one pending, one success)", calls)
+	}
+}
+
+func TestPollToken_AccessDenied(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"access_denied"}`))
+	}))
+	defer ts.Close()
+
+	dc := &DeviceCode{DeviceCode: "device-code", Interval: 1, ExpiresIn: 60}
+	_, err := PollToken(context.Background(), ts.Client(), ts.URL, dc)
+	if err != ErrAccessDenied {
+		t.Errorf("err = %v, want ErrAccessDenied", err)
+	}
+}
+
+func TestPollToken_ExpiredTokenFromServer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"expired_token"}`))
+	}))
+	defer ts.Close()
+
+	dc := &DeviceCode{DeviceCode: "device-code", Interval: 1, ExpiresIn: 60}
+	_, err := PollToken(context.Background(), ts.Client(), ts.URL, dc)
+	if err != ErrExpired {
+		t.Errorf("err = %v, want ErrExpired", err)
+	}
+}
+
+func TestPollToken_DeadlineExceededLocally(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"authorization_pending"}`))
+	}))
+	defer ts.Close()
+
+	dc := &DeviceCode{DeviceCode: "device-code", Interval: 1, ExpiresIn: 1}
+	_, err := PollToken(context.Background(), ts.Client(), ts.URL, dc)
+	if err != ErrExpired {
+		t.Errorf("err = %v, want ErrExpired", err)
+	}
+}
+
+func TestPollToken_ContextCanceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("server should not be contacted before the context is canceled")
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	dc := &DeviceCode{DeviceCode: "device-code", Interval: 5, ExpiresIn: 60}
+	_, err := PollToken(ctx, ts.Client(), ts.URL, dc)
+	if err != context.Canceled {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestRefreshAccessToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr bool
+		want    string
+	}{
+		{
+			name:   "success",
+			status: http.StatusOK,
+			body:   `{"access_token":"at-2","refresh_token":"rt-2"}`,
+			want:   "at-2",
+		},
+		{
+			name:    "server rejects the refresh token",
+			status:  http.StatusBadRequest,
+			body:    `{"error":"invalid_grant"}`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed JSON",
+			status:  http.StatusOK,
+			body:    `not json`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			defer ts.Close()
+
+			tok, err := RefreshAccessToken(context.Background(), ts.Client(), ts.URL, "refresh-token")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tok.AccessToken != tc.want {
+				t.Errorf("AccessToken = %q, want %q", tok.AccessToken, tc.want)
+			}
+		})
+	}
+}
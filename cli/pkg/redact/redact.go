@@ -1,6 +1,7 @@
 package redact
 
 import (
+	"fmt"
 	"math"
 	"regexp"
 	"strings"
@@ -13,7 +14,16 @@ type Pattern struct {
 	name        string
 }
 
-var patterns = []Pattern{
+// builtinPatterns are the rules shipped with the CLI. LoadUserRules merges
+// these with operator-supplied rules from a redact.yaml file into
+// activePatterns, which is what String/StringWithReport/Classify actually
+// scan against.
+var builtinPatterns = []Pattern{
+	// GitHub and GitLab personal access tokens (names match the analyzers'
+	// Type values so `roset debug analyze` can classify them)
+	{regexp.MustCompile(`ghp_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,}`), "REDACTED_GITHUB_TOKEN", "github_pat"},
+	{regexp.MustCompile(`glpat-[A-Za-z0-9_-]{20,}`), "REDACTED_GITLAB_TOKEN", "gitlab_pat"},
+
 	// Roset API keys
 	{regexp.MustCompile(`ros_[a-zA-Z0-9]{32,}`), "REDACTED_ROSET_KEY", "roset_key"},
 
@@ -61,10 +71,11 @@ func NewReport() *Report {
 	}
 }
 
-// String redacts sensitive information from a string.
+// String redacts sensitive information from a string, scanning the active
+// pattern set (built-ins plus any rules loaded via LoadUserRules).
 func String(input string) string {
 	result := input
-	for _, p := range patterns {
+	for _, p := range activePatternsSnapshot() {
 		result = p.regex.ReplaceAllString(result, p.replacement)
 	}
 	// Apply entropy-based redaction as a second pass
@@ -72,12 +83,25 @@ func String(input string) string {
 	return result
 }
 
+// Classify identifies which active pattern, if any, matches the input.
+// It returns the pattern name (e.g. "aws_access_key") or "" if nothing matches,
+// so callers like pkg/analyzer can auto-select a provider without re-implementing
+// the detection regexes.
+func Classify(input string) string {
+	for _, p := range activePatternsSnapshot() {
+		if p.regex.MatchString(input) {
+			return p.name
+		}
+	}
+	return ""
+}
+
 // StringWithReport redacts sensitive information and returns a report.
 func StringWithReport(input string, report *Report) string {
 	result := input
 
 	// Pass 1: Regex patterns
-	for _, p := range patterns {
+	for _, p := range activePatternsSnapshot() {
 		before := result
 		result = p.regex.ReplaceAllString(result, p.replacement)
 		if result != before {
@@ -132,8 +156,13 @@ func redactHighEntropy(input string) string {
 
 // redactHighEntropyWithCount finds and redacts high-entropy strings, returning count.
 func redactHighEntropyWithCount(input string) (string, int) {
+	threshold, minLength, enabled := entropySettings()
+	if !enabled {
+		return input, 0
+	}
+
 	// Match long alphanumeric strings that might be secrets
-	re := regexp.MustCompile(`[a-zA-Z0-9+/=_-]{20,}`)
+	re := regexp.MustCompile(fmt.Sprintf(`[a-zA-Z0-9+/=_-]{%d,}`, minLength))
 	count := 0
 
 	result := re.ReplaceAllStringFunc(input, func(match string) string {
@@ -147,7 +176,7 @@ func redactHighEntropyWithCount(input string) (string, int) {
 		}
 
 		entropy := calculateEntropy(match)
-		if entropy > highEntropyThreshold {
+		if entropy > threshold {
 			count++
 			return "REDACTED_HIGH_ENTROPY"
 		}
@@ -0,0 +1,201 @@
+package redact
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultEntropyMinLength is the minimum run length considered for the
+// entropy pass; it mirrors the `{20,}` literal the original implementation
+// hard-coded.
+const defaultEntropyMinLength = 20
+
+// UserPattern is one custom redaction rule loaded from a rules file.
+// Group is optional metadata recording which capturing group (if any) holds
+// the secret; it is parsed but not used for substitution and not shown by
+// `roset config redact list`. The actual substitution is driven by $N
+// references in Replacement, exactly like the built-in context-aware
+// patterns in redact.go.
+type UserPattern struct {
+	Name        string `yaml:"name"`
+	Regex       string `yaml:"regex"`
+	Replacement string `yaml:"replacement"`
+	Group       int    `yaml:"group,omitempty"`
+}
+
+// EntropyOverride tunes the high-entropy pass. A nil or zero-valued field
+// keeps the built-in default.
+type EntropyOverride struct {
+	Threshold float64 `yaml:"threshold"`
+	MinLength int      `yaml:"minLength"`
+	Enabled   *bool    `yaml:"enabled"`
+}
+
+// RuleFile is the schema of a user redaction rules file, defaulting to
+// ~/.roset/redact.yaml (path configurable via pkg/config's
+// RedactRulesPath).
+type RuleFile struct {
+	Rules   []UserPattern    `yaml:"rules"`
+	Disable []string         `yaml:"disable"`
+	Entropy *EntropyOverride `yaml:"entropy"`
+}
+
+// RuleSummary is one row of the effective rule set, as reported by
+// `roset config redact list`.
+type RuleSummary struct {
+	Name     string `json:"name"`
+	Source   string `json:"source"` // "builtin" or "user"
+	Disabled bool   `json:"disabled"`
+}
+
+var (
+	mu               sync.RWMutex
+	activePatterns   = append([]Pattern(nil), builtinPatterns...)
+	entropyThreshold = highEntropyThreshold
+	entropyMinLength = defaultEntropyMinLength
+	entropyEnabled   = true
+	loadedFrom       string
+	loadErr          error
+)
+
+// LoadUserRules reads path and merges its rules with the built-in patterns,
+// replacing the active pattern set and any entropy overrides it defines. A
+// missing file is not an error — it just resets to built-in defaults, which
+// makes this safe to call unconditionally on every CLI invocation.
+func LoadUserRules(path string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	loadedFrom = path
+	loadErr = nil
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			resetLocked()
+			return nil
+		}
+		loadErr = fmt.Errorf("reading redaction rules file %s: %w", path, err)
+		return loadErr
+	}
+
+	var rf RuleFile
+	if err := yaml.Unmarshal(data, &rf); err != nil {
+		loadErr = fmt.Errorf("parsing redaction rules file %s: %w", path, err)
+		return loadErr
+	}
+
+	disabled := make(map[string]bool, len(rf.Disable))
+	for _, name := range rf.Disable {
+		disabled[name] = true
+	}
+
+	merged := make([]Pattern, 0, len(builtinPatterns)+len(rf.Rules))
+	for _, p := range builtinPatterns {
+		if !disabled[p.name] {
+			merged = append(merged, p)
+		}
+	}
+
+	for _, up := range rf.Rules {
+		// Go's regexp package compiles to RE2 automata, which guarantee
+		// linear-time matching. Unlike backtracking engines, a malformed
+		// user-supplied pattern can't hang the CLI, so the only failure
+		// mode we need to guard against here is a straightforward compile
+		// error.
+		re, err := regexp.Compile(up.Regex)
+		if err != nil {
+			loadErr = fmt.Errorf("invalid regex for rule %q: %w", up.Name, err)
+			return loadErr
+		}
+		merged = append(merged, Pattern{regex: re, replacement: up.Replacement, name: up.Name})
+	}
+
+	activePatterns = merged
+
+	entropyThreshold = highEntropyThreshold
+	entropyMinLength = defaultEntropyMinLength
+	entropyEnabled = true
+	if rf.Entropy != nil {
+		if rf.Entropy.Threshold > 0 {
+			entropyThreshold = rf.Entropy.Threshold
+		}
+		if rf.Entropy.MinLength > 0 {
+			entropyMinLength = rf.Entropy.MinLength
+		}
+		if rf.Entropy.Enabled != nil {
+			entropyEnabled = *rf.Entropy.Enabled
+		}
+	}
+
+	return nil
+}
+
+func resetLocked() {
+	activePatterns = append([]Pattern(nil), builtinPatterns...)
+	entropyThreshold = highEntropyThreshold
+	entropyMinLength = defaultEntropyMinLength
+	entropyEnabled = true
+}
+
+// LoadError returns the error from the most recent LoadUserRules call, or
+// nil if it succeeded (or hasn't been called yet). cmd/doctor.go surfaces
+// this so a bad rules file shows up as an actionable diagnostic rather than
+// silently falling back to built-ins.
+func LoadError() error {
+	mu.RLock()
+	defer mu.RUnlock()
+	return loadErr
+}
+
+// LoadedFrom returns the path passed to the most recent LoadUserRules call.
+func LoadedFrom() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return loadedFrom
+}
+
+// EffectiveRules reports the full rule set — built-ins, user additions, and
+// which built-ins were disabled — for `roset config redact list`.
+func EffectiveRules() []RuleSummary {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	active := make(map[string]bool, len(activePatterns))
+	for _, p := range activePatterns {
+		active[p.name] = true
+	}
+
+	summaries := make([]RuleSummary, 0, len(builtinPatterns)+len(activePatterns))
+	for _, p := range builtinPatterns {
+		summaries = append(summaries, RuleSummary{Name: p.name, Source: "builtin", Disabled: !active[p.name]})
+	}
+
+	builtinNames := make(map[string]bool, len(builtinPatterns))
+	for _, p := range builtinPatterns {
+		builtinNames[p.name] = true
+	}
+	for _, p := range activePatterns {
+		if !builtinNames[p.name] {
+			summaries = append(summaries, RuleSummary{Name: p.name, Source: "user"})
+		}
+	}
+
+	return summaries
+}
+
+func activePatternsSnapshot() []Pattern {
+	mu.RLock()
+	defer mu.RUnlock()
+	return activePatterns
+}
+
+func entropySettings() (threshold float64, minLength int, enabled bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	return entropyThreshold, entropyMinLength, entropyEnabled
+}
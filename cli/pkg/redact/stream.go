@@ -0,0 +1,223 @@
+package redact
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+)
+
+// privateKeyCapBytes bounds how long the streaming redactor will buffer an
+// open `"private_key": "…` run before giving up and emitting a truncated
+// marker instead of growing memory without bound. It comfortably covers a
+// real PEM-encoded key (a few KB) with headroom.
+const privateKeyCapBytes = 64 * 1024
+
+// openPrivateKey matches the start of a GCP service-account private_key
+// field whose closing quote hasn't appeared yet on the current line.
+var openPrivateKey = regexp.MustCompile(`(?i)"private_key"\s*:\s*"`)
+
+// streamState is the bounded-memory buffering shared by NewReader and
+// NewWriter. It redacts complete lines as they arrive, except while inside
+// an open private_key run, which it buffers across line boundaries until
+// either a closing quote or the hard cap is reached.
+type streamState struct {
+	buf          []byte
+	report       *Report
+	inPrivateKey bool
+
+	// privateKeyPrefix holds the `"private_key": "` label (and anything
+	// before it on that line) for an in-progress run. It's held back rather
+	// than flushed immediately so the label and the value are redacted
+	// together as one unit once the run closes: the named gcp_private_key
+	// rule matches `"private_key":\s*"([^"]+)"` as a whole and never fires
+	// if the label and value are passed to redactChunk separately.
+	privateKeyPrefix []byte
+}
+
+func newStreamState(report *Report) *streamState {
+	if report == nil {
+		report = NewReport()
+	}
+	return &streamState{report: report}
+}
+
+// feed appends newly-read bytes and returns whatever redacted output is now
+// safe to emit, holding back only an incomplete trailing line (or an
+// in-progress private_key run) for the next call.
+func (s *streamState) feed(data []byte) []byte {
+	s.buf = append(s.buf, data...)
+	return s.drain(false)
+}
+
+// finish flushes any remaining buffered bytes, treating them as a final
+// (possibly incomplete) line.
+func (s *streamState) finish() []byte {
+	return s.drain(true)
+}
+
+func (s *streamState) drain(final bool) []byte {
+	var out []byte
+
+	for {
+		if s.inPrivateKey {
+			end := bytes.IndexByte(s.buf, '"')
+			if end == -1 {
+				if len(s.buf) < privateKeyCapBytes && !final {
+					break // wait for more input
+				}
+				// Hard cap (or EOF) reached mid-value: redact the label
+				// together with what we have of the value and stop tracking
+				// the run so we don't buffer forever.
+				out = append(out, s.redactChunk(append(s.privateKeyPrefix, s.buf...), true)...)
+				s.buf = nil
+				s.privateKeyPrefix = nil
+				s.inPrivateKey = false
+				if final {
+					break
+				}
+				continue
+			}
+			out = append(out, s.redactChunk(append(s.privateKeyPrefix, s.buf[:end+1]...), false)...)
+			s.buf = s.buf[end+1:]
+			s.privateKeyPrefix = nil
+			s.inPrivateKey = false
+			continue
+		}
+
+		nl := bytes.IndexByte(s.buf, '\n')
+		line := s.buf
+		if nl != -1 {
+			line = s.buf[:nl+1]
+		}
+		// Checked before waiting for a newline too, so a run opened on a
+		// line that never ends is still bounded by privateKeyCapBytes.
+		if loc := openPrivateKey.FindIndex(line); loc != nil && !bytes.ContainsRune(line[loc[1]:], '"') {
+			// The value opened on this line has no closing quote yet; hold
+			// everything up to (and including) the opening quote (instead
+			// of flushing it now) so it can be redacted together with the
+			// value once the run closes, and start tracking the run across
+			// subsequent reads.
+			s.privateKeyPrefix = append([]byte(nil), s.buf[:loc[1]]...)
+			s.buf = s.buf[loc[1]:]
+			s.inPrivateKey = true
+			continue
+		}
+
+		if nl == -1 {
+			if !final {
+				break // incomplete line, wait for more input
+			}
+			out = append(out, s.redactChunk(s.buf, false)...)
+			s.buf = nil
+			break
+		}
+
+		out = append(out, s.redactChunk(line, false)...)
+		s.buf = s.buf[nl+1:]
+	}
+
+	return out
+}
+
+func (s *streamState) redactChunk(chunk []byte, truncated bool) []byte {
+	if len(chunk) == 0 {
+		return nil
+	}
+	redacted := StringWithReport(string(chunk), s.report)
+	if truncated {
+		redacted += "…REDACTED_TRUNCATED…"
+	}
+	return []byte(redacted)
+}
+
+// redactReader wraps an io.Reader, scrubbing sensitive patterns from the
+// byte stream as it's consumed. It buffers only as much as a single line (or
+// an in-progress private_key run, capped at privateKeyCapBytes), so
+// arbitrarily large inputs can be redacted in bounded memory.
+type redactReader struct {
+	src    io.Reader
+	state  *streamState
+	out    []byte
+	srcErr error
+}
+
+// NewReader returns an io.Reader that redacts sensitive patterns from r as
+// bytes flow through, in bounded memory, without requiring the full input
+// to be read upfront like String does. Redaction counters are discarded; use
+// NewReaderWithReport to collect them.
+func NewReader(r io.Reader) io.Reader {
+	return &redactReader{src: r, state: newStreamState(nil)}
+}
+
+// NewReaderWithReport is like NewReader, but accumulates redaction counts
+// into report as matches are found, the same way StringWithReport does for
+// whole-string input.
+func NewReaderWithReport(r io.Reader, report *Report) io.Reader {
+	return &redactReader{src: r, state: newStreamState(report)}
+}
+
+func (rr *redactReader) Read(p []byte) (int, error) {
+	for len(rr.out) == 0 {
+		if rr.srcErr != nil {
+			rr.out = rr.state.finish()
+			if len(rr.out) == 0 {
+				return 0, rr.srcErr
+			}
+			break
+		}
+
+		buf := make([]byte, 32*1024)
+		n, err := rr.src.Read(buf)
+		rr.srcErr = err
+		if n > 0 {
+			rr.out = rr.state.feed(buf[:n])
+		}
+	}
+
+	n := copy(p, rr.out)
+	rr.out = rr.out[n:]
+	return n, nil
+}
+
+// redactWriter wraps an io.Writer, scrubbing sensitive patterns from each
+// write before forwarding it downstream.
+type redactWriter struct {
+	dst   io.Writer
+	state *streamState
+}
+
+// NewWriter returns an io.Writer that redacts sensitive patterns from data
+// before forwarding it to w, in bounded memory. Callers must Close the
+// returned writer to flush a final partial line (e.g. input with no
+// trailing newline). Redaction counters are discarded; use
+// NewWriterWithReport to collect them.
+func NewWriter(w io.Writer) io.WriteCloser {
+	return &redactWriter{dst: w, state: newStreamState(nil)}
+}
+
+// NewWriterWithReport is like NewWriter, but accumulates redaction counts
+// into report as matches are found, the same way StringWithReport does for
+// whole-string input.
+func NewWriterWithReport(w io.Writer, report *Report) io.WriteCloser {
+	return &redactWriter{dst: w, state: newStreamState(report)}
+}
+
+func (rw *redactWriter) Write(p []byte) (int, error) {
+	if out := rw.state.feed(p); len(out) > 0 {
+		if _, err := rw.dst.Write(out); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Close flushes any buffered-but-not-yet-redacted tail (an incomplete final
+// line) through the underlying writer.
+func (rw *redactWriter) Close() error {
+	if out := rw.state.finish(); len(out) > 0 {
+		if _, err := rw.dst.Write(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
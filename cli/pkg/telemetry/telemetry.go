@@ -0,0 +1,73 @@
+// Package telemetry configures the Roset CLI's OpenTelemetry tracer: an
+// OTLP/HTTP exporter enabled by ROSET_OTEL_EXPORTER_OTLP_ENDPOINT, or a
+// no-op tracer when that variable is unset, so every command pays nothing
+// for tracing unless an operator has pointed it at a collector.
+package telemetry
+
+import (
+	"context"
+	"os"
+
+	"github.com/roset-dev/roset/monorepo/cli/pkg/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the CLI's tracer in exported spans.
+const tracerName = "github.com/roset-dev/roset/monorepo/cli"
+
+// envEndpoint is the OTLP/HTTP collector endpoint. Unset disables tracing.
+const envEndpoint = "ROSET_OTEL_EXPORTER_OTLP_ENDPOINT"
+
+// Init installs the W3C trace-context propagator and, if envEndpoint is
+// set, an OTLP/HTTP exporter as the global tracer provider. It returns a
+// shutdown func that flushes and closes the exporter; call it once on exit.
+// If envEndpoint is unset, or the exporter fails to configure, it leaves
+// otel's default no-op tracer provider in place and returns a no-op
+// shutdown func, so callers never need to check whether tracing is enabled.
+func Init(version string) func(context.Context) error {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	endpoint := os.Getenv(envEndpoint)
+	if endpoint == "" {
+		return noopShutdown
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		log.Warn("failed to configure OTLP exporter, tracing disabled", "endpoint", endpoint, "error", err)
+		return noopShutdown
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceNameKey.String("roset-cli"),
+		semconv.ServiceVersionKey.String(version),
+	))
+	if err != nil {
+		log.Warn("failed to build telemetry resource", "error", err)
+		res = resource.Default()
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown
+}
+
+func noopShutdown(context.Context) error { return nil }
+
+// Tracer returns the CLI's tracer. It is always safe to call, even when
+// Init has not configured a real exporter.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}